@@ -0,0 +1,217 @@
+package extractor
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// BlockType is the type of a content Block.
+type BlockType int
+
+const (
+	BlockParagraph BlockType = iota + 1
+	BlockHeading
+	BlockList
+	BlockQuote
+	BlockCode
+	BlockTable
+)
+
+func (t BlockType) String() string {
+	switch t {
+	case BlockParagraph:
+		return "paragraph"
+	case BlockHeading:
+		return "heading"
+	case BlockList:
+		return "list"
+	case BlockQuote:
+		return "blockquote"
+	case BlockCode:
+		return "code"
+	case BlockTable:
+		return "table"
+	}
+	return "unknown"
+}
+
+// Block is a structural element of the content (paragraph, heading, list, ...).
+// Only the fields of its type are set.
+type Block struct {
+	Type BlockType
+	// Text of paragraphs, headings, blockquotes and code. The whitespace of code is kept.
+	Text string
+	// Level of headings, 1 to 6.
+	Level int
+	// Ordered tells whether a list is an <ol>. Items are the texts of its <li>.
+	Ordered bool
+	Items   []string
+	// Rows are the cell texts of a table, header row included.
+	Rows [][]string
+}
+
+// Elements whose text is not content.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true,
+	"iframe": true, "object": true, "embed": true, "canvas": true, "button": true,
+	"select": true, "textarea": true, "input": true, "form": true,
+	"nav": true, "header": true, "footer": true, "aside": true, "head": true,
+}
+
+// Elements flowing inside a paragraph. Consecutive inline content without a <p> around it
+// (e.g. text directly in a <div>) makes an implicit paragraph.
+var inlineElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdi": true, "bdo": true, "br": true, "cite": true,
+	"code": true, "data": true, "dfn": true, "em": true, "font": true, "i": true, "img": true,
+	"kbd": true, "label": true, "mark": true, "q": true, "s": true, "samp": true, "small": true,
+	"span": true, "strike": true, "strong": true, "sub": true, "sup": true, "time": true,
+	"tt": true, "u": true, "var": true, "wbr": true,
+}
+
+// blockWalker turns HTML nodes into Blocks, in document order.
+type blockWalker struct {
+	blocks []Block
+	inline strings.Builder
+}
+
+// getNodesBlocks returns the blocks of nodes themselves (not only of their children), in order.
+func getNodesBlocks(nodes []*html.Node) []Block {
+	w := &blockWalker{}
+	for _, node := range nodes {
+		w.node(node)
+	}
+	return w.done()
+}
+
+// blocksText returns the text of blocks, separated by spaces.
+func blocksText(blocks []Block) string {
+	var texts []string
+	for _, block := range blocks {
+		switch block.Type {
+		case BlockList:
+			texts = append(texts, block.Items...)
+		case BlockTable:
+			for _, row := range block.Rows {
+				texts = append(texts, row...)
+			}
+		default:
+			texts = append(texts, block.Text)
+		}
+	}
+	return normalizeSpace(strings.Join(texts, " "))
+}
+
+// getTextNodes turns plain text into <p> nodes, one per line, so it can be rendered like HTML content.
+func getTextNodes(text string) []*html.Node {
+	var nodes []*html.Node
+	for _, line := range strings.Split(text, "\n") {
+		if line = normalizeSpace(line); line != "" {
+			p := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P}
+			p.AppendChild(&html.Node{Type: html.TextNode, Data: line})
+			nodes = append(nodes, p)
+		}
+	}
+	return nodes
+}
+
+func (w *blockWalker) done() []Block {
+	w.flush()
+	return w.blocks
+}
+
+func (w *blockWalker) children(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		w.node(child)
+	}
+}
+
+func (w *blockWalker) node(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		w.inline.WriteString(node.Data)
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		w.children(node)
+		return
+	default:
+		return
+	}
+
+	name := node.Data
+	if skippedElements[name] {
+		return
+	}
+	if inlineElements[name] {
+		if name == "br" {
+			w.inline.WriteString(" ")
+		}
+		w.children(node)
+		return
+	}
+
+	w.flush()
+	selection := goquery.NewDocumentFromNode(node).Selection
+	switch name {
+	case "p":
+		w.add(Block{Type: BlockParagraph, Text: normalizeSpace(selection.Text())})
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.add(Block{Type: BlockHeading, Text: normalizeSpace(selection.Text()), Level: int(name[1] - '0')})
+	case "ul", "ol":
+		block := Block{Type: BlockList, Ordered: name == "ol"}
+		selection.ChildrenFiltered("li").Each(func(index int, item *goquery.Selection) {
+			if text := normalizeSpace(item.Text()); text != "" {
+				block.Items = append(block.Items, text)
+			}
+		})
+		if len(block.Items) != 0 {
+			w.blocks = append(w.blocks, block)
+		}
+	case "blockquote":
+		w.add(Block{Type: BlockQuote, Text: normalizeSpace(selection.Text())})
+	case "pre":
+		text := strings.Trim(selection.Text(), "\n")
+		if strings.TrimSpace(text) != "" {
+			w.blocks = append(w.blocks, Block{Type: BlockCode, Text: text})
+		}
+	case "table":
+		block := Block{Type: BlockTable}
+		selection.Find("tr").Each(func(index int, row *goquery.Selection) {
+			// Rows of nested tables belong to the nested table.
+			if row.Closest("table").Get(0) != node {
+				return
+			}
+			var cells []string
+			row.ChildrenFiltered("th, td").Each(func(index int, cell *goquery.Selection) {
+				cells = append(cells, normalizeSpace(cell.Text()))
+			})
+			if len(cells) != 0 {
+				block.Rows = append(block.Rows, cells)
+			}
+		})
+		if len(block.Rows) != 0 {
+			w.blocks = append(w.blocks, block)
+		}
+	default:
+		// Containers (div, section, article, li, ...).
+		w.children(node)
+		w.flush()
+	}
+}
+
+// flush turns the pending inline content into a paragraph.
+func (w *blockWalker) flush() {
+	text := normalizeSpace(w.inline.String())
+	w.inline.Reset()
+	w.add(Block{Type: BlockParagraph, Text: text})
+}
+
+// add appends block unless it has no text.
+func (w *blockWalker) add(block Block) {
+	if block.Text != "" {
+		w.blocks = append(w.blocks, block)
+	}
+}
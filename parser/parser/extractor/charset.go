@@ -0,0 +1,212 @@
+package extractor
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// The charsets tried by sniffCharset when the page does not declare one and is not UTF-8.
+// On equal scores the first one wins.
+var sniffedCharsets = []struct {
+	name  string
+	score func(r rune, previous rune) float64
+}{
+	{name: "windows-1252", score: latinScore("ðþýÐÞÝ")},
+	{name: "windows-1254", score: latinScore("")},
+	{name: "windows-1251", score: cyrillicScore},
+	{name: "koi8-r", score: cyrillicScore},
+	{name: "gbk", score: chineseScore},
+	{name: "shift_jis", score: japaneseScore},
+}
+
+// Score of the runes a wrong charset produces (invalid sequences, control characters).
+const invalidRuneScore = -5
+
+// decodeCharset detects the charset of an HTML page and transcodes it to UTF-8. The charset
+// comes from, in order: the byte order mark, the charset of contentType (the Content-Type
+// header, may be empty), the <meta charset> or http-equiv tags, then content sniffing.
+// It returns the UTF-8 content and the name of the detected charset.
+func decodeCharset(content []byte, contentType string) ([]byte, string) {
+	enc, name, certain := charset.DetermineEncoding(content, contentType)
+	// DetermineEncoding is only certain of the byte order mark and of contentType. It is not of
+	// the <meta> tags, which are scanned again to tell them from its windows-1252 fallback when
+	// nothing is declared (it only checks the first KB for UTF-8).
+	if !certain {
+		if metaEncoding, metaName := metaCharset(content); metaEncoding != nil {
+			enc, name = metaEncoding, metaName
+		} else if name == "windows-1252" {
+			enc, name = charset.Lookup(sniffCharset(content))
+		}
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(content)
+	if err != nil {
+		return content, "utf-8"
+	}
+	return bytes.TrimPrefix(decoded, []byte("\xef\xbb\xbf")), name
+}
+
+// metaCharset returns the encoding declared by the <meta charset> or http-equiv Content-Type
+// tags of the first KB of content and its name, or nil if there is none. Like browsers, UTF-16
+// declarations are read as UTF-8: the page would have a byte order mark otherwise.
+func metaCharset(content []byte) (encoding.Encoding, string) {
+	if len(content) > 1024 {
+		content = content[:1024]
+	}
+	z := html.NewTokenizer(bytes.NewReader(content))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return nil, ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "meta" || !hasAttr {
+				continue
+			}
+			var label, httpEquiv, metaContent string
+			for more := true; more; {
+				var key, value []byte
+				key, value, more = z.TagAttr()
+				switch string(key) {
+				case "charset":
+					label = string(value)
+				case "http-equiv":
+					httpEquiv = string(value)
+				case "content":
+					metaContent = string(value)
+				}
+			}
+			if label == "" && strings.EqualFold(httpEquiv, "content-type") {
+				label = contentCharset(metaContent)
+			}
+			if label == "" {
+				continue
+			}
+			if e, name := charset.Lookup(label); e != nil {
+				if strings.HasPrefix(name, "utf-16") {
+					return charset.Lookup("utf-8")
+				}
+				return e, name
+			}
+		}
+	}
+}
+
+// contentCharset returns the charset parameter of the content of an http-equiv Content-Type
+// tag, which is not always a valid media type.
+func contentCharset(content string) string {
+	i := strings.Index(strings.ToLower(content), "charset")
+	if i < 0 {
+		return ""
+	}
+	value := strings.TrimLeft(content[i+len("charset"):], " \t")
+	if !strings.HasPrefix(value, "=") {
+		return ""
+	}
+	value = strings.Trim(strings.TrimLeft(value[1:], " \t"), `"'`)
+	if end := strings.IndexAny(value, " \t;\"'"); end >= 0 {
+		value = value[:end]
+	}
+	return value
+}
+
+// sniffCharset guesses the charset of content: UTF-8 if it is valid, else the charset whose
+// decoded text looks the most like natural language.
+func sniffCharset(content []byte) string {
+	if utf8.Valid(content) {
+		return "utf-8"
+	}
+
+	best, bestScore := "windows-1252", 0.0
+	for i, candidate := range sniffedCharsets {
+		enc, _ := charset.Lookup(candidate.name)
+		decoded, err := enc.NewDecoder().Bytes(content)
+		if err != nil {
+			continue
+		}
+		score, previous := 0.0, rune(0)
+		for _, r := range string(decoded) {
+			switch {
+			case r < utf8.RuneSelf:
+			case r == utf8.RuneError || unicode.IsControl(r):
+				score += invalidRuneScore
+			default:
+				score += candidate.score(r, previous)
+			}
+			previous = r
+		}
+		if i == 0 || score > bestScore {
+			best, bestScore = candidate.name, score
+		}
+	}
+	return best
+}
+
+// latinScore scores the non-ASCII runes of Latin languages: accented letters, usually alone
+// between ASCII letters. The unlikely letters are those which are more likely the letters of
+// another charset (e.g. the Icelandic ones of windows-1252 are Turkish ones in windows-1254).
+func latinScore(unlikely string) func(r rune, previous rune) float64 {
+	return func(r rune, previous rune) float64 {
+		if !unicode.Is(unicode.Latin, r) {
+			return 0
+		}
+		if strings.ContainsRune(unlikely, r) || (previous >= utf8.RuneSelf && unicode.IsLetter(previous)) {
+			// Words made of accented letters only are another script decoded as Latin.
+			return -1
+		}
+		return 1
+	}
+}
+
+// cyrillicScore scores Cyrillic letters, mostly lower case in natural text. Decoding a
+// Cyrillic charset with another one swaps the cases.
+func cyrillicScore(r rune, previous rune) float64 {
+	switch {
+	case !unicode.Is(unicode.Cyrillic, r):
+		return 0
+	case previous < utf8.RuneSelf && unicode.IsLetter(previous):
+		// Words mixing ASCII and Cyrillic letters are a Latin language decoded as Cyrillic.
+		return -1
+	case unicode.IsLower(r):
+		return 1
+	}
+	return 0.25
+}
+
+// chineseScore scores the runes of Chinese text, per byte (they take two bytes in GBK). Only
+// the GB2312 characters, whose bytes are all above 0xA0, are common: other bytes decoded as GBK
+// make rare characters from the extension, often with an ASCII byte.
+func chineseScore(r rune, previous rune) float64 {
+	if !unicode.Is(unicode.Han, r) && !isCJKPunctuation(r) {
+		return 0
+	}
+	encoded, err := simplifiedchinese.GBK.NewEncoder().String(string(r))
+	if err != nil || len(encoded) != 2 || encoded[0] < 0xa1 || encoded[1] < 0xa1 {
+		return -1
+	}
+	return 2
+}
+
+// japaneseScore scores the runes of Japanese text, per byte (they take two bytes in Shift_JIS).
+// Kana are the most telling; half-width katakana are rare in text and common in other
+// charsets decoded as Shift_JIS.
+func japaneseScore(r rune, previous rune) float64 {
+	switch {
+	case unicode.Is(unicode.Hiragana, r) || (unicode.Is(unicode.Katakana, r) && r < 0xff00):
+		return 3
+	case unicode.Is(unicode.Han, r) || isCJKPunctuation(r):
+		return 2
+	}
+	return 0
+}
+
+func isCJKPunctuation(r rune) bool {
+	return (r >= 0x3000 && r <= 0x303f) || (r >= 0xff01 && r <= 0xff5e)
+}
@@ -0,0 +1,65 @@
+package extractor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html/charset"
+)
+
+func TestDecodeCharset(t *testing.T) {
+	const (
+		turkish  = "Türkiye'nin başkenti Ankara, en kalabalık şehri İstanbul'dur. Çiçekler ağaçların altında güzel görünüyor."
+		russian  = "Москва — столица России, крупнейший по численности населения город страны. Здесь много музеев и театров."
+		japanese = "東京は日本の首都であり、世界でも有数の大都市です。多くの人々がここで働いています。"
+		chinese  = "北京是中华人民共和国的首都，也是全国的政治和文化中心。这里有很多历史悠久的名胜古迹。"
+		french   = "Le café est très apprécié en France, où l'on déguste des croissants à la boulangerie."
+		// Decoded as windows-1254 (Turkish), Þ and ð would be Ş and ğ.
+		icelandic = "Þetta er íslenskur texti. Það var gaman að sjá þig í gær."
+		english   = "Plain ASCII text."
+	)
+	encode := func(name, text string) []byte {
+		encoding, _ := charset.Lookup(name)
+		encoded, err := encoding.NewEncoder().String(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []byte(encoded)
+	}
+	page := func(head, text string) string {
+		return "<html><head>" + head + "</head><body><p>" + text + "</p></body></html>"
+	}
+
+	tests := []struct {
+		name        string
+		content     []byte
+		contentType string
+		wantCharset string
+		wantText    string
+	}{
+		{name: "utf-8", content: []byte(page("", turkish)), wantCharset: "utf-8", wantText: turkish},
+		{name: "bom", content: append([]byte("\xef\xbb\xbf"), page("", russian)...), wantCharset: "utf-8", wantText: russian},
+		{name: "header", content: encode("windows-1254", page("", turkish)), contentType: "text/html; charset=windows-1254", wantCharset: "windows-1254", wantText: turkish},
+		{name: "meta charset", content: encode("koi8-r", page(`<meta charset="koi8-r">`, russian)), wantCharset: "koi8-r", wantText: russian},
+		{name: "meta latin-1", content: encode("windows-1252", page(`<meta charset="iso-8859-1">`, icelandic)), wantCharset: "windows-1252", wantText: icelandic},
+		{name: "meta latin-1 ascii", content: []byte(page(`<meta charset="iso-8859-1">`, english)), wantCharset: "windows-1252", wantText: english},
+		{name: "http-equiv latin-1", content: encode("windows-1252", page(`<meta http-equiv="content-type" content="text/html;charset='ISO-8859-1'">`, icelandic)), wantCharset: "windows-1252", wantText: icelandic},
+		{name: "http-equiv", content: encode("shift_jis", page(`<meta http-equiv="Content-Type" content="text/html; charset=Shift_JIS">`, japanese)), wantCharset: "shift_jis", wantText: japanese},
+		{name: "sniffed windows-1254", content: encode("windows-1254", page("", turkish)), wantCharset: "windows-1254", wantText: turkish},
+		{name: "sniffed windows-1251", content: encode("windows-1251", page("", russian)), wantCharset: "windows-1251", wantText: russian},
+		{name: "sniffed koi8-r", content: encode("koi8-r", page("", russian)), wantCharset: "koi8-r", wantText: russian},
+		{name: "sniffed shift_jis", content: encode("shift_jis", page("", japanese)), wantCharset: "shift_jis", wantText: japanese},
+		{name: "sniffed gbk", content: encode("gbk", page("", chinese)), wantCharset: "gbk", wantText: chinese},
+		{name: "sniffed windows-1252", content: encode("windows-1252", page("", french)), wantCharset: "windows-1252", wantText: french},
+	}
+	for _, tt := range tests {
+		result, err := New().ExtractFromReader(context.Background(), strings.NewReader(string(tt.content)), "", WithContentType(tt.contentType))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Charset != tt.wantCharset || result.Content != tt.wantText {
+			t.Errorf("%s: expected %s %q, got %s %q", tt.name, tt.wantCharset, tt.wantText, result.Charset, result.Content)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package extractor
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// The media types of the feeds advertised by <link rel="alternate">.
+var feedTypes = []string{"application/rss+xml", "application/atom+xml", "application/feed+json", "application/rdf+xml"}
+
+// The media types of the oEmbed endpoints advertised by <link rel="alternate">.
+var oEmbedTypes = []string{"application/json+oembed", "text/xml+oembed", "application/xml+oembed"}
+
+// AlternateLink is another representation of a page advertised in its <head>: a feed or an
+// oEmbed endpoint.
+type AlternateLink struct {
+	URL string
+	// Type is the media type of the link, e.g. "application/rss+xml".
+	Type  string
+	Title string
+}
+
+// getDiscoveredLinks fills the feeds, oEmbed endpoints and AMP and canonical URLs advertised by
+// the <link> elements of the document. Relative URLs are resolved against baseURL.
+func getDiscoveredLinks(document *goquery.Document, baseURL string, result *Result) {
+	document.Find("link[rel][href]").Each(func(index int, item *goquery.Selection) {
+		href := resolveURL(baseURL, item.AttrOr("href", ""))
+		if href == "" {
+			return
+		}
+		switch {
+		case hasRel(item, "canonical"):
+			if result.CanonicalURL == "" {
+				result.CanonicalURL = href
+			}
+		case hasRel(item, "amphtml"):
+			if result.AMPURL == "" {
+				result.AMPURL = href
+			}
+		case hasRel(item, "alternate"):
+			mediaType, _, _ := mime.ParseMediaType(item.AttrOr("type", ""))
+			link := AlternateLink{URL: href, Type: mediaType, Title: strings.TrimSpace(item.AttrOr("title", ""))}
+			if contains(feedTypes, mediaType) {
+				result.Feeds = appendAlternateLink(result.Feeds, link)
+			}
+			if contains(oEmbedTypes, mediaType) {
+				result.OEmbedEndpoints = appendAlternateLink(result.OEmbedEndpoints, link)
+			}
+		}
+	})
+}
+
+// hasRel tells whether the space separated rel attribute of a link contains rel.
+func hasRel(link *goquery.Selection, rel string) bool {
+	for _, value := range strings.Fields(link.AttrOr("rel", "")) {
+		if strings.EqualFold(value, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendAlternateLink appends link to links unless its URL is already there.
+func appendAlternateLink(links []AlternateLink, link AlternateLink) []AlternateLink {
+	for _, l := range links {
+		if l.URL == link.URL {
+			return links
+		}
+	}
+	return append(links, link)
+}
@@ -0,0 +1,120 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrorKind classifies the errors returned by the Extractor, so callers (e.g. the gRPC
+// server) can map them without comparing error strings.
+type ErrorKind int
+
+const (
+	// KindUnknown is an error that does not fit any other kind.
+	KindUnknown ErrorKind = iota
+	// KindInvalidInput means the given URL is malformed or not supported.
+	KindInvalidInput
+	// KindNotFound means the host or the page does not exist.
+	KindNotFound
+	// KindUnavailable means the page could not be fetched (connection refused, reset, ...).
+	KindUnavailable
+	// KindTimeout means fetching the page took too long.
+	KindTimeout
+	// KindUnparsable means the page was fetched but could not be parsed.
+	KindUnparsable
+	// KindTooLarge means the page is larger than the accepted body size, or its decompressed
+	// content is (PDF documents).
+	KindTooLarge
+	// KindCanceled means the request was cancelled by the caller.
+	KindCanceled
+	// KindPermissionDenied means the server refused to serve the page (401, 403, 451).
+	KindPermissionDenied
+	// KindBadStatus means the server answered with an unexpected error status.
+	KindBadStatus
+	// KindUnsupportedType means the page is neither HTML, plain text, an image nor a PDF document.
+	KindUnsupportedType
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindInvalidInput:
+		return "invalid input"
+	case KindNotFound:
+		return "not found"
+	case KindUnavailable:
+		return "unavailable"
+	case KindTimeout:
+		return "timeout"
+	case KindUnparsable:
+		return "unparsable"
+	case KindTooLarge:
+		return "too large"
+	case KindCanceled:
+		return "canceled"
+	case KindPermissionDenied:
+		return "permission denied"
+	case KindBadStatus:
+		return "bad status"
+	case KindUnsupportedType:
+		return "unsupported type"
+	}
+	return "unknown"
+}
+
+// Error is the error type returned by the Extractor.
+type Error struct {
+	Kind ErrorKind
+	// URL is the URL that was being processed, if any.
+	URL string
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.URL == "" {
+		return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.Kind, e.URL, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the kind of err, or KindUnknown if err is not an *Error.
+func KindOf(err error) ErrorKind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindUnknown
+}
+
+// contextError returns an error of kind KindCanceled or KindTimeout if ctx is done, nil otherwise.
+func contextError(ctx context.Context, inputUrl string) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return &Error{Kind: KindTimeout, URL: inputUrl, Err: ctx.Err()}
+	default:
+		return &Error{Kind: KindCanceled, URL: inputUrl, Err: ctx.Err()}
+	}
+}
+
+// fetchError classifies an error returned by the HTTP client.
+func fetchError(inputUrl string, err error) *Error {
+	if errors.Is(err, context.Canceled) {
+		return &Error{Kind: KindCanceled, URL: inputUrl, Err: err}
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return &Error{Kind: KindNotFound, URL: inputUrl, Err: err}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &Error{Kind: KindTimeout, URL: inputUrl, Err: err}
+	}
+	return &Error{Kind: KindUnavailable, URL: inputUrl, Err: err}
+}
@@ -0,0 +1,422 @@
+// Package extractor parses a web page (news page, blog page, ...) and extracts its
+// title, thumbnail image URL and text content.
+//
+// It is the library behind the gRPC "ParseURL" service, so other Go services can
+// embed it directly without running the server.
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/url"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Result holds the values extracted from a single page. Values that are not found
+// in the page are left empty.
+type Result struct {
+	// URL is the final address the page was read from, after redirects (empty if unknown).
+	URL string
+	// ContentType is the media type of the page, e.g. "text/html" (empty if unknown).
+	// Charset is the detected charset of the page, e.g. "windows-1254". The page is transcoded
+	// to UTF-8 before it is parsed.
+	ContentType string
+	Charset     string
+
+	Title        string
+	ThumbnailURL string
+	Content      string
+	// Blocks are the structural elements of the content (paragraphs, headings, lists, ...),
+	// in document order.
+	Blocks []Block
+	// ContentMarkdown and ContentHTML are the content as Markdown and as sanitized HTML. They are
+	// only set when requested with WithMarkdown and WithSanitizedHTML.
+	ContentMarkdown string
+	ContentHTML     string
+	// Images are the best ranked image candidates, best first. ThumbnailURL is the first one
+	// which is not rejected, unless its score is negative.
+	Images []ImageCandidate
+	// ThumbnailProbe is the type and dimensions of the thumbnail, only set with WithImageProbing.
+	ThumbnailProbe *ImageProbe
+
+	Description  string
+	CanonicalURL string
+	SiteName     string
+	Authors      []string
+	// PublishedTime and ModifiedTime are zero if the page does not provide them.
+	PublishedTime time.Time
+	ModifiedTime  time.Time
+	Language      string
+	Keywords      []string
+	FaviconURL    string
+	WordCount     int
+	// PageCount is the number of pages of a PDF document, zero for other pages.
+	PageCount int
+
+	// AMPURL is the AMP version of the page (<link rel="amphtml">). Feeds and OEmbedEndpoints are
+	// advertised by <link rel="alternate">, in page order.
+	AMPURL          string
+	Feeds           []AlternateLink
+	OEmbedEndpoints []AlternateLink
+
+	// OpenGraph and TwitterCard are nil if the page has no such tags.
+	OpenGraph   *OpenGraph
+	TwitterCard *TwitterCard
+	// StructuredData is the schema.org article of the page, nil if it has none.
+	// JSONLD holds the raw content of every JSON-LD block of the page.
+	StructuredData *StructuredData
+	JSONLD         []string
+
+	// Extractor is the name of the SiteExtractor which handled the page, ReadabilityExtractorName
+	// if the content was detected by ContentModeReadability, or GenericExtractorName.
+	Extractor string
+}
+
+// Extractor fetches and parses web pages.
+type Extractor struct {
+	fetcher *Fetcher
+	sites   *Registry
+}
+
+// Option configures an Extractor.
+type Option func(*Extractor)
+
+// WithSites sets the registry of site specific extractors. DefaultRegistry is used otherwise.
+func WithSites(sites *Registry) Option {
+	return func(e *Extractor) {
+		e.sites = sites
+	}
+}
+
+// WithFetcher sets the Fetcher downloading the pages. A Fetcher with DefaultFetcherConfig is used otherwise.
+func WithFetcher(fetcher *Fetcher) Option {
+	return func(e *Extractor) {
+		e.fetcher = fetcher
+	}
+}
+
+// New returns an Extractor with the given options.
+func New(options ...Option) *Extractor {
+	e := &Extractor{sites: DefaultRegistry()}
+	for _, option := range options {
+		option(e)
+	}
+	if e.fetcher == nil {
+		e.fetcher = NewFetcher(DefaultFetcherConfig())
+	}
+	return e
+}
+
+// ContentMode selects how the content of a page is detected.
+type ContentMode int
+
+const (
+	// ContentModeDefault uses the site extractors, JSON-LD and the generic parser of the paragraphs,
+	// lists, headings, quotes, code and tables of the body.
+	ContentModeDefault ContentMode = iota
+	// ContentModeReadability scores the blocks of the page to find the main content and removes
+	// the boilerplate (navigation, footers, cookie banners, comments, ...). If nothing looks like
+	// content, ContentModeDefault is used.
+	ContentModeReadability
+)
+
+// extractOptions are the per-request options of ExtractFromURL and ExtractFromReader.
+type extractOptions struct {
+	contentMode   ContentMode
+	markdown      bool
+	sanitizedHTML bool
+	maxImages     int
+	probeImages   bool
+	fetch         FetchOptions
+	contentType   string
+	progress      func(ProgressStage, *Result)
+}
+
+// ExtractOption configures a single extraction.
+type ExtractOption func(*extractOptions)
+
+// WithContentMode selects how the content of the page is detected.
+func WithContentMode(mode ContentMode) ExtractOption {
+	return func(o *extractOptions) {
+		o.contentMode = mode
+	}
+}
+
+// WithMarkdown also returns the content as Markdown, in Result.ContentMarkdown.
+func WithMarkdown() ExtractOption {
+	return func(o *extractOptions) {
+		o.markdown = true
+	}
+}
+
+// WithSanitizedHTML also returns the content as sanitized HTML, in Result.ContentHTML: only
+// allowlisted tags and attributes are kept, links and images are absolute http(s) URLs.
+func WithSanitizedHTML() ExtractOption {
+	return func(o *extractOptions) {
+		o.sanitizedHTML = true
+	}
+}
+
+// WithMaxImages sets the number of ranked image candidates returned in Result.Images.
+func WithMaxImages(n int) ExtractOption {
+	return func(o *extractOptions) {
+		if n >= 0 {
+			o.maxImages = n
+		}
+	}
+}
+
+// WithImageProbing fetches the first bytes of the best ranked images to check their type and
+// dimensions. Broken and tiny images (e.g. tracking pixels) are not used as thumbnail.
+func WithImageProbing() ExtractOption {
+	return func(o *extractOptions) {
+		o.probeImages = true
+	}
+}
+
+// WithFetchOptions overrides the configuration of the Fetcher for the request, within its limits.
+func WithFetchOptions(fetch FetchOptions) ExtractOption {
+	return func(o *extractOptions) {
+		o.fetch = fetch
+	}
+}
+
+// WithContentType sets the Content-Type header the page was served with, whose charset is
+// used to decode the page. ExtractFromURL sets it from the response.
+func WithContentType(contentType string) ExtractOption {
+	return func(o *extractOptions) {
+		o.contentType = contentType
+	}
+}
+
+// ProgressStage is a stage of an extraction reported by WithProgress.
+type ProgressStage int
+
+const (
+	// ProgressFetched is reported once the page is fetched: the URL and content type are known.
+	ProgressFetched ProgressStage = iota + 1
+	// ProgressMetadata is reported once the title and the metadata of an HTML page are extracted.
+	ProgressMetadata
+	// ProgressThumbnail is reported once the images of an HTML page are ranked (and probed with
+	// WithImageProbing), before the content is extracted.
+	ProgressThumbnail
+)
+
+// WithProgress calls progress with the partial result at every stage of the extraction, before
+// the complete result is returned. It is called from the goroutine of the extraction, and the
+// partial result must not be kept or modified after the call returns.
+func WithProgress(progress func(stage ProgressStage, partial *Result)) ExtractOption {
+	return func(o *extractOptions) {
+		o.progress = progress
+	}
+}
+
+func newExtractOptions(options []ExtractOption) *extractOptions {
+	o := &extractOptions{maxImages: DefaultMaxImages}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// report calls the progress function, if any.
+func (o *extractOptions) report(stage ProgressStage, partial *Result) {
+	if o.progress != nil {
+		o.progress(stage, partial)
+	}
+}
+
+// renderContent sets the blocks of the content nodes in result, and the requested formats.
+func (o *extractOptions) renderContent(result *Result, nodes []*html.Node, baseURL string) {
+	result.Blocks = getNodesBlocks(nodes)
+	if o.markdown {
+		result.ContentMarkdown = renderMarkdown(nodes, baseURL)
+	}
+	if o.sanitizedHTML {
+		result.ContentHTML = renderSanitizedHTML(nodes, baseURL)
+	}
+}
+
+// ExtractFromURL downloads the page at inputUrl and extracts its title, thumbnail and content.
+// HTML pages are parsed, plain text is returned as the content, images as the thumbnail and
+// the text and metadata of PDF documents are extracted; error statuses and other content types fail.
+func (e *Extractor) ExtractFromURL(ctx context.Context, inputUrl string, options ...ExtractOption) (*Result, error) {
+	// Check URL validity
+	parsedUrl, err := url.ParseRequestURI(inputUrl)
+	if err != nil {
+		return nil, &Error{Kind: KindInvalidInput, URL: inputUrl, Err: err}
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return nil, &Error{Kind: KindInvalidInput, URL: inputUrl, Err: fmt.Errorf("unsupported URL scheme %q", parsedUrl.Scheme)}
+	}
+
+	// HTTP Request
+	opts := newExtractOptions(options)
+	response, err := e.fetcher.Fetch(ctx, inputUrl, opts.fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := statusError(response); err != nil {
+		return nil, err
+	}
+	return e.extractResponse(ctx, response, opts)
+}
+
+// ExtractFromReader parses the HTML page read from r. baseURL is the address the page
+// was served from and may be empty (e.g. for local files).
+func (e *Extractor) ExtractFromReader(ctx context.Context, r io.Reader, baseURL string, options ...ExtractOption) (*Result, error) {
+	return e.extractReader(ctx, r, baseURL, newExtractOptions(options))
+}
+
+// extractReader parses the HTML page read from r with the options of the request.
+func (e *Extractor) extractReader(ctx context.Context, r io.Reader, baseURL string, opts *extractOptions) (*Result, error) {
+	// The context is checked between the extraction stages, so cancelled requests stop early.
+	if err := contextError(ctx, baseURL); err != nil {
+		return nil, err
+	}
+
+	// Transcode the page to UTF-8 before parsing it.
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, &Error{Kind: KindUnavailable, URL: baseURL, Err: err}
+	}
+	content, pageCharset := decodeCharset(content, opts.contentType)
+
+	// Create a goquery document from the reader
+	document, err := goquery.NewDocumentFromReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, &Error{Kind: KindUnparsable, URL: baseURL, Err: err}
+	}
+	if err := contextError(ctx, baseURL); err != nil {
+		return nil, err
+	}
+
+	// Relative URLs of the page are resolved against its <base href>, if any.
+	pageBase := getBaseURL(document, baseURL)
+	// The media type is also known here when the page was fetched.
+	mediaType, _, _ := mime.ParseMediaType(opts.contentType)
+	result := &Result{
+		URL:         baseURL,
+		ContentType: mediaType,
+		Charset:     pageCharset,
+		OpenGraph:   getOpenGraph(document, pageBase),
+		TwitterCard: getTwitterCard(document, pageBase),
+	}
+	result.JSONLD, result.StructuredData = getJSONLD(document, pageBase)
+	og, twitter, data := result.OpenGraph, result.TwitterCard, result.StructuredData
+	if og == nil {
+		og = &OpenGraph{}
+	}
+	if twitter == nil {
+		twitter = &TwitterCard{}
+	}
+	if data == nil {
+		data = &StructuredData{}
+	}
+
+	// Site specific extractors know the page best. Then Open Graph, Twitter Card and
+	// JSON-LD are the most reliable signals, the page heuristics are only used when they are missing.
+	var pageUrl *url.URL
+	if baseURL != "" {
+		pageUrl, _ = url.Parse(baseURL)
+	}
+	var site SiteResult
+	result.Extractor, site = e.sites.Extract(pageUrl, document)
+	if result.Extractor == "" {
+		result.Extractor = GenericExtractorName
+	}
+	if err := contextError(ctx, baseURL); err != nil {
+		return nil, err
+	}
+
+	result.Title = firstNonEmpty(site.Title, og.Title, twitter.Title, data.Headline)
+	if result.Title == "" {
+		result.Title = getTitle(document)
+	}
+
+	getMetadata(document, pageBase, result)
+	if result.Description == "" {
+		result.Description = firstNonEmpty(og.Description, twitter.Description, data.Description)
+	}
+	if len(result.Authors) == 0 {
+		result.Authors = data.Authors
+	}
+	if result.PublishedTime.IsZero() {
+		result.PublishedTime = data.DatePublished
+	}
+	if result.ModifiedTime.IsZero() {
+		result.ModifiedTime = data.DateModified
+	}
+	if len(result.Keywords) == 0 {
+		result.Keywords = data.Keywords
+	}
+	if result.SiteName == "" {
+		result.SiteName = data.Publisher
+	}
+	opts.report(ProgressMetadata, result)
+
+	// The thumbnail is the best ranked image, unless all of them look like logos, pixels, ...
+	images := rankImages(document, pageBase, site.ThumbnailURL, og, twitter, data)
+	if opts.probeImages {
+		probed := images
+		if len(probed) > maxProbedImages {
+			probed = probed[:maxProbedImages]
+		}
+		e.probeImages(ctx, probed, opts.fetch)
+		if err := contextError(ctx, baseURL); err != nil {
+			return nil, err
+		}
+	}
+	for _, image := range images {
+		if image.Score < 0 {
+			break
+		}
+		if !image.Rejected {
+			result.ThumbnailURL, result.ThumbnailProbe = image.URL, image.Probe
+			break
+		}
+	}
+	if len(images) > opts.maxImages {
+		images = images[:opts.maxImages]
+	}
+	result.Images = images
+	opts.report(ProgressThumbnail, result)
+
+	var nodes []*html.Node
+	if opts.contentMode == ContentModeReadability {
+		result.Content, nodes = getReadableContent(document)
+		if result.Content != "" {
+			result.Extractor = ReadabilityExtractorName
+		}
+		if err := contextError(ctx, baseURL); err != nil {
+			return nil, err
+		}
+	}
+	if result.Content == "" && site.Content != "" {
+		result.Content, nodes = site.Content, site.Nodes
+		if nodes == nil {
+			nodes = getTextNodes(site.Content)
+		}
+	}
+	if result.Content == "" && data.ArticleBody != "" {
+		result.Content, nodes = data.ArticleBody, getTextNodes(data.ArticleBody)
+	}
+	if result.Content == "" {
+		result.Content, nodes = getContent(document)
+	}
+	opts.renderContent(result, nodes, pageBase)
+	if err := contextError(ctx, baseURL); err != nil {
+		return nil, err
+	}
+
+	result.WordCount = countWords(result.Content)
+	return result, nil
+}
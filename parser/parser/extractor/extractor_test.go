@@ -0,0 +1,86 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractHonorsContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	const page = `<html><head><title>Title</title></head><body><p>Stuff to p1</p></body></html>`
+	for ctx, want := range map[context.Context]ErrorKind{canceled: KindCanceled, expired: KindTimeout} {
+		_, err := New().ExtractFromReader(ctx, strings.NewReader(page), "")
+		if KindOf(err) != want {
+			t.Errorf("Expected %s, got %v", want, err)
+		}
+	}
+}
+
+func TestExtractFromURLStopsFetchOnCancel(t *testing.T) {
+	stopped := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		close(stopped)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := New().ExtractFromURL(ctx, server.URL)
+	if KindOf(err) != KindCanceled {
+		t.Errorf("Expected %s, got %v", KindCanceled, err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Error("Expected the download to stop")
+	}
+}
+
+func TestExtractReportsProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><head><title>Title</title><meta name="description" content="Description">
+			<meta property="og:image" content="/image.jpg"></head><body><p>Stuff to p1</p></body></html>`))
+	}))
+	defer server.Close()
+
+	var stages []ProgressStage
+	result, err := New().ExtractFromURL(context.Background(), server.URL, WithProgress(func(stage ProgressStage, partial *Result) {
+		stages = append(stages, stage)
+		switch stage {
+		case ProgressFetched:
+			if partial.URL != server.URL || partial.ContentType != "text/html" || partial.Title != "" {
+				t.Errorf("Unexpected fetched result %+v", partial)
+			}
+		case ProgressMetadata:
+			if partial.Title != "Title" || partial.Description != "Description" || partial.ContentType != "text/html" || partial.ThumbnailURL != "" {
+				t.Errorf("Unexpected metadata result %+v", partial)
+			}
+		case ProgressThumbnail:
+			if partial.ThumbnailURL != server.URL+"/image.jpg" || partial.Content != "" {
+				t.Errorf("Unexpected thumbnail result %+v", partial)
+			}
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []ProgressStage{ProgressFetched, ProgressMetadata, ProgressThumbnail}; !reflect.DeepEqual(stages, want) {
+		t.Errorf("Expected stages %v, got %v", want, stages)
+	}
+	if result.Content != "Stuff to p1" {
+		t.Errorf("Unexpected content %q", result.Content)
+	}
+}
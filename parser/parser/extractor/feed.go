@@ -0,0 +1,583 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html/charset"
+)
+
+// The formats reported in Feed.Format.
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+	FeedFormatJSON = "json"
+)
+
+const (
+	// maxParsedEntries is the largest number of entries extracted with FeedOptions.ParseEntries.
+	maxParsedEntries = 25
+	// feedParseConcurrency is the number of entries extracted at the same time.
+	feedParseConcurrency = 4
+	// maxSummaryRunes is the length of the longest entry summary, the longer ones (e.g. whole
+	// articles in the content) are cut at a word boundary.
+	maxSummaryRunes = 1000
+)
+
+// The date formats of feeds besides dateLayouts. RSS uses RFC 822 dates, often with one digit days.
+var feedDateLayouts = []string{
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"Mon, 2 January 2006 15:04:05 MST",
+}
+
+// errNotFeed is returned for documents which are not RSS, Atom or JSON feeds.
+var errNotFeed = errors.New("not an RSS, Atom or JSON feed")
+
+// Feed holds the values extracted from an RSS, Atom or JSON feed. Values that are not found in
+// the feed are left empty.
+type Feed struct {
+	// URL is the final address the feed was read from, after redirects.
+	URL string
+	// Format is FeedFormatRSS (RSS 2.0 and 1.0), FeedFormatAtom or FeedFormatJSON.
+	Format      string
+	Title       string
+	Description string
+	// Link is the address of the site of the feed.
+	Link     string
+	Language string
+	ImageURL string
+	Updated  time.Time
+	// Entries are in the order of the feed.
+	Entries []FeedEntry
+}
+
+// FeedEntry is an entry (item) of a feed.
+type FeedEntry struct {
+	ID    string
+	Title string
+	Link  string
+	// Published and Updated are zero if the entry does not provide them.
+	Published time.Time
+	Updated   time.Time
+	// Summary is the plain text of the summary, or of the content if there is none, cut to
+	// 1000 characters.
+	Summary string
+	// ImageURL is the enclosure or media image of the entry, or the first image of its content.
+	ImageURL string
+	Authors  []string
+
+	// Article is the result of ExtractFromURL on Link, only set with FeedOptions.ParseEntries.
+	// ArticleErr is set instead if the extraction failed.
+	Article    *Result
+	ArticleErr error
+}
+
+// FeedOptions configure ExtractFeed.
+type FeedOptions struct {
+	// MaxEntries is the number of entries returned, all of them if zero.
+	MaxEntries int
+	// ParseEntries extracts the article of every returned entry (the first 25 at most).
+	ParseEntries bool
+}
+
+// ExtractFeed downloads the feed at feedUrl and extracts its metadata and entries. The options
+// are used to fetch the feed, and to extract the entries with FeedOptions.ParseEntries.
+func (e *Extractor) ExtractFeed(ctx context.Context, feedUrl string, feedOptions FeedOptions, options ...ExtractOption) (*Feed, error) {
+	parsedUrl, err := url.ParseRequestURI(feedUrl)
+	if err != nil {
+		return nil, &Error{Kind: KindInvalidInput, URL: feedUrl, Err: err}
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return nil, &Error{Kind: KindInvalidInput, URL: feedUrl, Err: fmt.Errorf("unsupported URL scheme %q", parsedUrl.Scheme)}
+	}
+
+	response, err := e.fetcher.Fetch(ctx, feedUrl, newExtractOptions(options).fetch)
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(response); err != nil {
+		return nil, err
+	}
+
+	feed, err := parseFeed(response.Body, response.URL)
+	if err == errNotFeed {
+		return nil, &Error{Kind: KindUnsupportedType, URL: response.URL, Err: err}
+	}
+	if err != nil {
+		return nil, &Error{Kind: KindUnparsable, URL: response.URL, Err: err}
+	}
+	if feedOptions.MaxEntries > 0 && len(feed.Entries) > feedOptions.MaxEntries {
+		feed.Entries = feed.Entries[:feedOptions.MaxEntries]
+	}
+	if feedOptions.ParseEntries {
+		e.extractEntries(ctx, feed.Entries, options)
+		if err := contextError(ctx, feedUrl); err != nil {
+			return nil, err
+		}
+	}
+	return feed, nil
+}
+
+// extractEntries extracts the articles of the entries concurrently.
+func (e *Extractor) extractEntries(ctx context.Context, entries []FeedEntry, options []ExtractOption) {
+	if len(entries) > maxParsedEntries {
+		entries = entries[:maxParsedEntries]
+	}
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, feedParseConcurrency)
+	for i := range entries {
+		wg.Add(1)
+		go func(entry *FeedEntry) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if entry.Link == "" {
+				entry.ArticleErr = &Error{Kind: KindInvalidInput, Err: errors.New("entry without link")}
+				return
+			}
+			entry.Article, entry.ArticleErr = e.ExtractFromURL(ctx, entry.Link, options...)
+		}(&entries[i])
+	}
+	wg.Wait()
+}
+
+// parseFeed parses an RSS, Atom or JSON feed. Relative URLs are resolved against baseURL.
+func parseFeed(content []byte, baseURL string) (*Feed, error) {
+	content = bytes.TrimLeft(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if bytes.HasPrefix(content, []byte("{")) {
+		return parseJSONFeed(content, baseURL)
+	}
+
+	decoder := newFeedDecoder(content)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// Not XML, or XML without elements.
+			return nil, errNotFeed
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "rss", "RDF":
+			var rss rssDocument
+			if err := decoder.DecodeElement(&rss, &start); err != nil {
+				return nil, err
+			}
+			return rss.feed(baseURL), nil
+		case "feed":
+			var atom atomFeed
+			if err := decoder.DecodeElement(&atom, &start); err != nil {
+				return nil, err
+			}
+			return atom.feed(baseURL), nil
+		}
+		return nil, errNotFeed
+	}
+}
+
+// newFeedDecoder returns a lenient XML decoder: feeds often use HTML entities, unquoted
+// attributes and charsets other than UTF-8. HTML void elements are not closed, <link> is not
+// one in feeds.
+func newFeedDecoder(content []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = charset.NewReaderLabel
+	return decoder
+}
+
+// xmlLink is an RSS <link>URL</link> or an Atom <link href="URL"/>.
+type xmlLink struct {
+	Href  string `xml:"href,attr"`
+	Rel   string `xml:"rel,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// xmlMedia is an RSS <enclosure>, a Media RSS <media:content>/<media:thumbnail> or an iTunes <itunes:image>.
+type xmlMedia struct {
+	URL    string `xml:"url,attr"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+// rssDocument is an RSS 2.0 <rss> or RSS 1.0 <rdf:RDF> document. RSS 1.0 items are siblings
+// of the channel.
+type rssDocument struct {
+	Channel rssChannel `xml:"channel"`
+	Items   []rssItem  `xml:"item"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Links         []xmlLink  `xml:"link"`
+	Description   string     `xml:"description"`
+	Language      string     `xml:"language"`
+	Images        []rssImage `xml:"image"`
+	PubDate       string     `xml:"pubDate"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	Date          string     `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Items         []rssItem  `xml:"item"`
+}
+
+// rssImage is an RSS <image><url>URL</url></image> or an iTunes <itunes:image href="URL"/>.
+type rssImage struct {
+	URL  string `xml:"url"`
+	Href string `xml:"href,attr"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title"`
+	Links       []xmlLink  `xml:"link"`
+	GUID        string     `xml:"guid"`
+	PubDate     string     `xml:"pubDate"`
+	Date        string     `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Description string     `xml:"description"`
+	Encoded     string     `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Authors     []string   `xml:"author"`
+	Creators    []string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Enclosures  []xmlMedia `xml:"enclosure"`
+	Media       []xmlMedia `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails  []xmlMedia `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	Groups      []struct {
+		Media      []xmlMedia `xml:"http://search.yahoo.com/mrss/ content"`
+		Thumbnails []xmlMedia `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	} `xml:"http://search.yahoo.com/mrss/ group"`
+	ITunesImages []xmlMedia `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+}
+
+func (rss *rssDocument) feed(baseURL string) *Feed {
+	channel := rss.Channel
+	feed := &Feed{
+		URL:         baseURL,
+		Format:      FeedFormatRSS,
+		Title:       htmlText(channel.Title),
+		Description: htmlText(channel.Description),
+		Link:        resolveURL(baseURL, linkURL(channel.Links)),
+		Language:    strings.TrimSpace(channel.Language),
+		Updated:     parseFeedDate(channel.LastBuildDate, channel.PubDate, channel.Date),
+	}
+	for _, image := range channel.Images {
+		if src := firstNonEmpty(strings.TrimSpace(image.URL), strings.TrimSpace(image.Href)); src != "" {
+			feed.ImageURL = resolveURL(baseURL, src)
+			break
+		}
+	}
+
+	for _, item := range append(channel.Items, rss.Items...) {
+		entry := FeedEntry{
+			ID:        strings.TrimSpace(item.GUID),
+			Title:     htmlText(item.Title),
+			Link:      resolveURL(baseURL, linkURL(item.Links)),
+			Published: parseFeedDate(item.PubDate, item.Date),
+			Summary:   summaryText(htmlText(firstNonEmpty(strings.TrimSpace(item.Description), item.Encoded))),
+		}
+		// A permalink GUID is the link of items without one.
+		if entry.Link == "" && strings.HasPrefix(entry.ID, "http") {
+			entry.Link = entry.ID
+		}
+		for _, author := range append(item.Authors, item.Creators...) {
+			if author = strings.TrimSpace(author); author != "" && !contains(entry.Authors, author) {
+				entry.Authors = append(entry.Authors, author)
+			}
+		}
+
+		media := append(append(item.Enclosures, item.Thumbnails...), item.Media...)
+		for _, group := range item.Groups {
+			media = append(append(media, group.Thumbnails...), group.Media...)
+		}
+		media = append(media, item.ITunesImages...)
+		entry.ImageURL = resolveURL(baseURL, firstNonEmpty(mediaImage(media), htmlImage(item.Encoded), htmlImage(item.Description)))
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+type atomFeed struct {
+	Language string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Title    atomText    `xml:"title"`
+	Subtitle atomText    `xml:"subtitle"`
+	Links    []xmlLink   `xml:"link"`
+	Updated  string      `xml:"updated"`
+	Icon     string      `xml:"icon"`
+	Logo     string      `xml:"logo"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+// atomText is an Atom text construct, whose type is "text", "html" or "xhtml".
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+func (t atomText) text() string {
+	switch t.Type {
+	case "xhtml":
+		return htmlText(t.Inner)
+	case "html":
+		return htmlText(t.Value)
+	}
+	return normalizeSpace(t.Value)
+}
+
+// html returns the HTML of the text, if it has some.
+func (t atomText) html() string {
+	switch t.Type {
+	case "xhtml":
+		return t.Inner
+	case "html":
+		return t.Value
+	}
+	return ""
+}
+
+type atomEntry struct {
+	ID        string    `xml:"id"`
+	Title     atomText  `xml:"title"`
+	Links     []xmlLink `xml:"link"`
+	Published string    `xml:"published"`
+	Updated   string    `xml:"updated"`
+	Summary   atomText  `xml:"summary"`
+	Content   atomText  `xml:"content"`
+	Authors   []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Thumbnails []xmlMedia `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	Media      []xmlMedia `xml:"http://search.yahoo.com/mrss/ content"`
+}
+
+func (atom *atomFeed) feed(baseURL string) *Feed {
+	feed := &Feed{
+		URL:         baseURL,
+		Format:      FeedFormatAtom,
+		Title:       atom.Title.text(),
+		Description: atom.Subtitle.text(),
+		Link:        resolveURL(baseURL, linkURL(atom.Links)),
+		Language:    strings.TrimSpace(atom.Language),
+		ImageURL:    resolveURL(baseURL, firstNonEmpty(strings.TrimSpace(atom.Logo), strings.TrimSpace(atom.Icon))),
+		Updated:     parseFeedDate(atom.Updated),
+	}
+	for _, item := range atom.Entries {
+		entry := FeedEntry{
+			ID:        strings.TrimSpace(item.ID),
+			Title:     item.Title.text(),
+			Link:      resolveURL(baseURL, linkURL(item.Links)),
+			Published: parseFeedDate(item.Published),
+			Updated:   parseFeedDate(item.Updated),
+			Summary:   summaryText(firstNonEmpty(item.Summary.text(), item.Content.text())),
+		}
+		for _, author := range item.Authors {
+			if name := strings.TrimSpace(author.Name); name != "" && !contains(entry.Authors, name) {
+				entry.Authors = append(entry.Authors, name)
+			}
+		}
+
+		media := append(item.Thumbnails, item.Media...)
+		for _, link := range item.Links {
+			if link.Rel == "enclosure" {
+				media = append(media, xmlMedia{URL: link.Href, Type: link.Type})
+			}
+		}
+		entry.ImageURL = resolveURL(baseURL, firstNonEmpty(mediaImage(media), htmlImage(item.Content.html()), htmlImage(item.Summary.html())))
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+// jsonFeed is a JSON Feed (https://jsonfeed.org) document, version 1 or 1.1.
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Description string         `json:"description"`
+	Icon        string         `json:"icon"`
+	Favicon     string         `json:"favicon"`
+	Language    string         `json:"language"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	// IDs should be strings, some feeds use numbers.
+	ID            interface{}      `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	Summary       string           `json:"summary"`
+	ContentText   string           `json:"content_text"`
+	ContentHTML   string           `json:"content_html"`
+	Image         string           `json:"image"`
+	BannerImage   string           `json:"banner_image"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Author        *jsonFeedAuthor  `json:"author"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Attachments   []struct {
+		URL      string `json:"url"`
+		MimeType string `json:"mime_type"`
+	} `json:"attachments"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func parseJSONFeed(content []byte, baseURL string) (*Feed, error) {
+	var document jsonFeed
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, errNotFeed
+	}
+	if !strings.Contains(document.Version, "jsonfeed.org") {
+		return nil, errNotFeed
+	}
+
+	feed := &Feed{
+		URL:         baseURL,
+		Format:      FeedFormatJSON,
+		Title:       strings.TrimSpace(document.Title),
+		Description: strings.TrimSpace(document.Description),
+		Link:        resolveURL(baseURL, document.HomePageURL),
+		Language:    strings.TrimSpace(document.Language),
+		ImageURL:    resolveURL(baseURL, firstNonEmpty(document.Icon, document.Favicon)),
+	}
+	for _, item := range document.Items {
+		entry := FeedEntry{
+			Title:     strings.TrimSpace(item.Title),
+			Link:      resolveURL(baseURL, firstNonEmpty(item.URL, item.ExternalURL)),
+			Published: parseFeedDate(item.DatePublished),
+			Updated:   parseFeedDate(item.DateModified),
+			Summary:   summaryText(firstNonEmpty(normalizeSpace(item.Summary), normalizeSpace(item.ContentText), htmlText(item.ContentHTML))),
+		}
+		if item.ID != nil {
+			entry.ID = strings.TrimSpace(fmt.Sprint(item.ID))
+		}
+		authors := item.Authors
+		if item.Author != nil {
+			authors = append(authors, *item.Author)
+		}
+		for _, author := range authors {
+			if name := strings.TrimSpace(author.Name); name != "" && !contains(entry.Authors, name) {
+				entry.Authors = append(entry.Authors, name)
+			}
+		}
+
+		var media []xmlMedia
+		for _, attachment := range item.Attachments {
+			media = append(media, xmlMedia{URL: attachment.URL, Type: attachment.MimeType})
+		}
+		entry.ImageURL = resolveURL(baseURL, firstNonEmpty(item.Image, item.BannerImage, mediaImage(media), htmlImage(item.ContentHTML)))
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed, nil
+}
+
+// linkURL returns the URL of the first alternate link.
+func linkURL(links []xmlLink) string {
+	for _, link := range links {
+		if link.Rel != "" && link.Rel != "alternate" {
+			continue
+		}
+		if href := firstNonEmpty(strings.TrimSpace(link.Value), strings.TrimSpace(link.Href)); href != "" {
+			return href
+		}
+	}
+	return ""
+}
+
+// mediaImage returns the URL of the first image media, by type, medium or file extension.
+func mediaImage(media []xmlMedia) string {
+	for _, m := range media {
+		src := strings.TrimSpace(firstNonEmpty(m.URL, m.Href))
+		if src == "" {
+			continue
+		}
+		if strings.HasPrefix(m.Type, "image/") || m.Medium == "image" {
+			return src
+		}
+		if m.Type == "" && m.Medium == "" {
+			path := strings.ToLower(strings.SplitN(src, "?", 2)[0])
+			for _, extension := range []string{".jpg", ".jpeg", ".png", ".gif", ".webp"} {
+				if strings.HasSuffix(path, extension) {
+					return src
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// htmlText returns the normalized text of an HTML fragment.
+func htmlText(fragment string) string {
+	if !strings.ContainsAny(fragment, "<&") {
+		return normalizeSpace(fragment)
+	}
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return normalizeSpace(fragment)
+	}
+	return normalizeSpace(document.Text())
+}
+
+// summaryText cuts text to maxSummaryRunes at a word boundary, with an ellipsis.
+func summaryText(text string) string {
+	if utf8.RuneCountInString(text) <= maxSummaryRunes {
+		return text
+	}
+	cut := string([]rune(text)[:maxSummaryRunes])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// htmlImage returns the source of the first image of an HTML fragment.
+func htmlImage(fragment string) string {
+	if !strings.Contains(fragment, "<img") {
+		return ""
+	}
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return ""
+	}
+	src := ""
+	document.Find("img").EachWithBreak(func(index int, image *goquery.Selection) bool {
+		src = imageSource(image)
+		return src == ""
+	})
+	return src
+}
+
+// parseFeedDate parses the first of the values which is a valid date. It returns the zero time
+// if none is.
+func parseFeedDate(values ...string) time.Time {
+	for _, value := range values {
+		if t := parseDate(value); !t.IsZero() {
+			return t
+		}
+		value = strings.TrimSpace(value)
+		for _, layout := range feedDateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,207 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+const testRSS = `<?xml version="1.0" encoding="ISO-8859-1"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:media="http://search.yahoo.com/mrss/" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+<channel>
+	<title>Caf` + "\xe9" + ` News</title>
+	<link>https://example.com/</link>
+	<atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml"/>
+	<description>The latest news</description>
+	<language>en-us</language>
+	<image><url>/logo.png</url><title>Logo</title></image>
+	<lastBuildDate>Tue, 3 Mar 2020 10:00:00 GMT</lastBuildDate>
+	<item>
+		<title>First story</title>
+		<link>/first</link>
+		<guid isPermaLink="false">story-1</guid>
+		<pubDate>Mon, 2 Mar 2020 08:30:00 +0100</pubDate>
+		<description><![CDATA[<p>The <b>first</b>&nbsp;story.</p>]]></description>
+		<dc:creator>Jane Doe</dc:creator>
+		<enclosure url="https://example.com/audio.mp3" type="audio/mpeg" length="1"/>
+		<media:thumbnail url="https://example.com/first.jpg"/>
+	</item>
+	<item>
+		<title>Second story</title>
+		<guid>https://example.com/second</guid>
+		<content:encoded><![CDATA[<p><img src="/second.png"> Second story.</p>]]></content:encoded>
+	</item>
+</channel>
+</rss>`
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="fr">
+	<title type="html">Le &lt;b&gt;blog&lt;/b&gt;</title>
+	<subtitle>Notes</subtitle>
+	<link href="https://blog.example.com/feed" rel="self"/>
+	<link href="https://blog.example.com/"/>
+	<updated>2020-03-03T10:00:00Z</updated>
+	<logo>https://blog.example.com/logo.png</logo>
+	<entry>
+		<title>Entry</title>
+		<id>urn:uuid:1</id>
+		<link rel="alternate" href="/entry"/>
+		<link rel="enclosure" type="image/jpeg" href="/entry.jpg"/>
+		<published>2020-03-02T08:30:00+01:00</published>
+		<updated>2020-03-02T09:30:00+01:00</updated>
+		<author><name>Jean</name></author>
+		<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Entry <em>content</em>.</p></div></content>
+	</entry>
+</feed>`
+
+const testJSONFeed = `{
+	"version": "https://jsonfeed.org/version/1.1",
+	"title": "JSON News",
+	"home_page_url": "https://json.example.com/",
+	"icon": "/icon.png",
+	"items": [
+		{
+			"id": 42,
+			"url": "https://json.example.com/42",
+			"title": "Answer",
+			"content_html": "<p>The <em>answer</em>.</p><img src='/42.png'>",
+			"date_published": "2020-03-02T08:30:00Z",
+			"authors": [{"name": "Deep Thought"}]
+		}
+	]
+}`
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		content string
+		want    *Feed
+	}{
+		{content: testRSS, want: &Feed{
+			URL: "https://example.com/feed.xml", Format: FeedFormatRSS, Title: "Café News", Description: "The latest news",
+			Link: "https://example.com/", Language: "en-us", ImageURL: "https://example.com/logo.png",
+			Updated: time.Date(2020, 3, 3, 10, 0, 0, 0, time.UTC),
+			Entries: []FeedEntry{{
+				ID: "story-1", Title: "First story", Link: "https://example.com/first",
+				Published: time.Date(2020, 3, 2, 7, 30, 0, 0, time.UTC), Summary: "The first story.",
+				ImageURL: "https://example.com/first.jpg", Authors: []string{"Jane Doe"},
+			}, {
+				ID: "https://example.com/second", Title: "Second story", Link: "https://example.com/second",
+				Summary: "Second story.", ImageURL: "https://example.com/second.png",
+			}},
+		}},
+		{content: testAtom, want: &Feed{
+			URL: "https://example.com/feed.xml", Format: FeedFormatAtom, Title: "Le blog", Description: "Notes",
+			Link: "https://blog.example.com/", Language: "fr", ImageURL: "https://blog.example.com/logo.png",
+			Updated: time.Date(2020, 3, 3, 10, 0, 0, 0, time.UTC),
+			Entries: []FeedEntry{{
+				ID: "urn:uuid:1", Title: "Entry", Link: "https://example.com/entry",
+				Published: time.Date(2020, 3, 2, 7, 30, 0, 0, time.UTC), Updated: time.Date(2020, 3, 2, 8, 30, 0, 0, time.UTC),
+				Summary: "Entry content.", ImageURL: "https://example.com/entry.jpg", Authors: []string{"Jean"},
+			}},
+		}},
+		{content: testJSONFeed, want: &Feed{
+			URL: "https://example.com/feed.xml", Format: FeedFormatJSON, Title: "JSON News",
+			Link: "https://json.example.com/", ImageURL: "https://example.com/icon.png",
+			Entries: []FeedEntry{{
+				ID: "42", Title: "Answer", Link: "https://json.example.com/42",
+				Published: time.Date(2020, 3, 2, 8, 30, 0, 0, time.UTC), Summary: "The answer.",
+				ImageURL: "https://example.com/42.png", Authors: []string{"Deep Thought"},
+			}},
+		}},
+	}
+	for _, tt := range tests {
+		feed, err := parseFeed([]byte(tt.content), "https://example.com/feed.xml")
+		if err != nil {
+			t.Errorf("%s: %v", tt.want.Format, err)
+			continue
+		}
+		for i := range feed.Entries {
+			entry, want := feed.Entries[i], tt.want.Entries[i]
+			if !entry.Published.Equal(want.Published) || !entry.Updated.Equal(want.Updated) {
+				t.Errorf("%s: expected dates %s %s, got %s %s", tt.want.Format, want.Published, want.Updated, entry.Published, entry.Updated)
+			}
+			feed.Entries[i].Published, feed.Entries[i].Updated = want.Published, want.Updated
+		}
+		if !feed.Updated.Equal(tt.want.Updated) {
+			t.Errorf("%s: expected update %s, got %s", tt.want.Format, tt.want.Updated, feed.Updated)
+		}
+		feed.Updated = tt.want.Updated
+		if !reflect.DeepEqual(feed, tt.want) {
+			t.Errorf("%s: expected\n%+v\ngot\n%+v", tt.want.Format, tt.want, feed)
+		}
+	}
+
+	for _, content := range []string{"<html><body>Page</body></html>", `{"title": "JSON"}`, "Plain text"} {
+		if _, err := parseFeed([]byte(content), ""); err != errNotFeed {
+			t.Errorf("%q: expected %v, got %v", content, errNotFeed, err)
+		}
+	}
+}
+
+func TestParseFeedLongSummary(t *testing.T) {
+	// A whole article in the content, without description.
+	article := strings.Repeat("Lorem ipsum dolor sit amet. ", 1000)
+	rss := `<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/"><channel><title>News</title>
+<item><title>Long</title><content:encoded><![CDATA[<p>` + article + `</p>]]></content:encoded></item></channel></rss>`
+	feed, err := parseFeed([]byte(rss), "https://example.com/feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := feed.Entries[0].Summary
+	if utf8.RuneCountInString(summary) > maxSummaryRunes+1 || !strings.HasSuffix(summary, "…") || !strings.HasPrefix(article, strings.TrimSuffix(summary, "…")+" ") {
+		t.Errorf("expected a summary cut at a word boundary, got %q", summary)
+	}
+	if got := summaryText("Short summary."); got != "Short summary." {
+		t.Errorf("expected the short summary unchanged, got %q", got)
+	}
+}
+
+func TestExtractFeed(t *testing.T) {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprintf(w, `<rss><channel><title>Feed</title>
+			<item><title>One</title><link>%[1]s/one</link></item>
+			<item><title>Missing</title><link>%[1]s/missing</link></item>
+			<item><title>Three</title><link>%[1]s/three</link></item>
+			</channel></rss>`, server.URL)
+	})
+	mux.HandleFunc("/one", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><title>One article</title></head><body><p>Text of one</p></body></html>"))
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><title>Page</title></head></html>"))
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	e := New()
+	feed, err := e.ExtractFeed(context.Background(), server.URL+"/feed", FeedOptions{MaxEntries: 2, ParseEntries: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed.Title != "Feed" || len(feed.Entries) != 2 {
+		t.Fatalf("unexpected feed %+v", feed)
+	}
+	if article := feed.Entries[0].Article; article == nil || article.Title != "One article" || article.Content != "Text of one" {
+		t.Errorf("unexpected article %+v", article)
+	}
+	if entry := feed.Entries[1]; entry.Article != nil || KindOf(entry.ArticleErr) != KindNotFound {
+		t.Errorf("expected a not found error, got %+v", entry)
+	}
+
+	if _, err := e.ExtractFeed(context.Background(), server.URL+"/page", FeedOptions{}); KindOf(err) != KindUnsupportedType {
+		t.Errorf("expected %s, got %v", KindUnsupportedType, err)
+	}
+	if _, err := e.ExtractFeed(context.Background(), "ftp://example.com/feed", FeedOptions{}); KindOf(err) != KindInvalidInput {
+		t.Errorf("expected %s, got %v", KindInvalidInput, err)
+	}
+}
@@ -0,0 +1,242 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultUserAgent is the User-Agent of DefaultFetcherConfig. Many sites block the default
+// Go User-Agent.
+const DefaultUserAgent = "Mozilla/5.0 (compatible; go-grpc-basic-url-parser/1.0; +https://github.com/hbahadirsahin/go-grpc-basic-url-parser)"
+
+// FetcherConfig configures a Fetcher. Its timeouts and body size are also the upper limits of
+// the per-request FetchOptions. Zero durations and sizes mean no limit.
+type FetcherConfig struct {
+	// ConnectTimeout bounds the connection to the server, TLS handshake included.
+	ConnectTimeout time.Duration
+	// ReadTimeout bounds the wait for the response headers and every read of the body.
+	ReadTimeout time.Duration
+	// TotalTimeout bounds the whole fetch, redirects and body included.
+	TotalTimeout time.Duration
+	// MaxBodyBytes is the largest accepted body, larger pages fail with KindTooLarge.
+	MaxBodyBytes int64
+
+	UserAgent      string
+	AcceptLanguage string
+	// Headers are added to every request.
+	Headers map[string]string
+}
+
+// DefaultFetcherConfig returns the configuration used by New.
+func DefaultFetcherConfig() FetcherConfig {
+	return FetcherConfig{
+		ConnectTimeout: 5 * time.Second,
+		ReadTimeout:    10 * time.Second,
+		TotalTimeout:   30 * time.Second,
+		MaxBodyBytes:   10 << 20,
+		UserAgent:      DefaultUserAgent,
+	}
+}
+
+// FetchOptions override the FetcherConfig for a single request. Timeouts and sizes above the
+// ones of the config are capped. Zero values keep the config ones.
+type FetchOptions struct {
+	// Timeout, ConnectTimeout and ReadTimeout override the TotalTimeout, ConnectTimeout and
+	// ReadTimeout of the config. ConnectTimeout does not apply to the TLS handshake, nor to the
+	// connections reused from the pool.
+	Timeout        time.Duration
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	MaxBodyBytes   int64
+	UserAgent      string
+	AcceptLanguage string
+	// Headers are added to the config ones, replacing those with the same name.
+	Headers map[string]string
+}
+
+// FetchResponse is a fetched page.
+type FetchResponse struct {
+	// URL is the final URL of the page, after redirects.
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Fetcher downloads pages with timeouts, a body size limit and configurable headers.
+// It is safe for concurrent use.
+type Fetcher struct {
+	client *http.Client
+	config FetcherConfig
+}
+
+// connectTimeoutKey is the context key of the connect timeout of a request.
+type connectTimeoutKey struct{}
+
+// NewFetcher returns a Fetcher with the given configuration.
+func NewFetcher(config FetcherConfig) *Fetcher {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{Timeout: config.ConnectTimeout, KeepAlive: 30 * time.Second}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		// The connect timeout of the request, if lower than the one of the dialer.
+		if timeout, ok := ctx.Value(connectTimeoutKey{}).(time.Duration); ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+	transport.TLSHandshakeTimeout = config.ConnectTimeout
+	transport.ResponseHeaderTimeout = config.ReadTimeout
+	return &Fetcher{client: &http.Client{Transport: transport}, config: config}
+}
+
+// Config returns the configuration of the fetcher.
+func (f *Fetcher) Config() FetcherConfig {
+	return f.config
+}
+
+// Fetch downloads the page at pageUrl.
+func (f *Fetcher) Fetch(ctx context.Context, pageUrl string, options FetchOptions) (*FetchResponse, error) {
+	timeout := capDuration(options.Timeout, f.config.TotalTimeout)
+	connectTimeout := capDuration(options.ConnectTimeout, f.config.ConnectTimeout)
+	readTimeout := capDuration(options.ReadTimeout, f.config.ReadTimeout)
+	maxBodyBytes := capInt64(options.MaxBodyBytes, f.config.MaxBodyBytes)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if connectTimeout > 0 {
+		ctx = context.WithValue(ctx, connectTimeoutKey{}, connectTimeout)
+	}
+	// Cancelled when the response headers or the body are not received for readTimeout.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var idle int32
+	var timer *time.Timer
+	if readTimeout > 0 {
+		timer = time.AfterFunc(readTimeout, func() {
+			atomic.StoreInt32(&idle, 1)
+			cancel()
+		})
+		timer.Stop()
+		defer timer.Stop()
+		// Started once the request (or the one of a redirect) is sent.
+		ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+			WroteRequest: func(httptrace.WroteRequestInfo) {
+				timer.Reset(readTimeout)
+			},
+		})
+	}
+	readTimeoutError := func(err error) error {
+		if atomic.LoadInt32(&idle) == 1 {
+			return fmt.Errorf("no data received for %s: %w", readTimeout, errReadTimeout)
+		}
+		return err
+	}
+
+	request, err := f.newRequest(ctx, pageUrl, options)
+	if err != nil {
+		return nil, &Error{Kind: KindInvalidInput, URL: pageUrl, Err: err}
+	}
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fetchError(pageUrl, readTimeoutError(err))
+	}
+	defer response.Body.Close()
+
+	var body io.Reader = response.Body
+	if timer != nil {
+		timer.Reset(readTimeout)
+		body = &idleTimeoutReader{r: body, timeout: readTimeout, timer: timer}
+	}
+	if maxBodyBytes > 0 {
+		if response.ContentLength > maxBodyBytes {
+			return nil, &Error{Kind: KindTooLarge, URL: pageUrl, Err: fmt.Errorf("body of %d bytes exceeds the limit of %d bytes", response.ContentLength, maxBodyBytes)}
+		}
+		// One more byte to tell a body of exactly maxBodyBytes from a larger one.
+		body = io.LimitReader(body, maxBodyBytes+1)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, fetchError(pageUrl, readTimeoutError(err))
+	}
+	if maxBodyBytes > 0 && int64(len(data)) > maxBodyBytes {
+		return nil, &Error{Kind: KindTooLarge, URL: pageUrl, Err: fmt.Errorf("body exceeds the limit of %d bytes", maxBodyBytes)}
+	}
+
+	return &FetchResponse{
+		URL:        response.Request.URL.String(),
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       data,
+	}, nil
+}
+
+// newRequest returns a GET request for pageUrl with the configured and requested headers.
+func (f *Fetcher) newRequest(ctx context.Context, pageUrl string, options FetchOptions) (*http.Request, error) {
+	request, err := http.NewRequest(http.MethodGet, pageUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	for name, value := range f.config.Headers {
+		request.Header.Set(name, value)
+	}
+	for name, value := range options.Headers {
+		request.Header.Set(name, value)
+	}
+	if userAgent := firstNonEmpty(options.UserAgent, f.config.UserAgent); userAgent != "" {
+		request.Header.Set("User-Agent", userAgent)
+	}
+	if language := firstNonEmpty(options.AcceptLanguage, f.config.AcceptLanguage); language != "" {
+		request.Header.Set("Accept-Language", language)
+	}
+	return request, nil
+}
+
+// errReadTimeout is a net.Error timeout, so read timeouts are reported as KindTimeout.
+var errReadTimeout error = readTimeoutError{}
+
+type readTimeoutError struct{}
+
+func (readTimeoutError) Error() string   { return "read timeout" }
+func (readTimeoutError) Timeout() bool   { return true }
+func (readTimeoutError) Temporary() bool { return true }
+
+// idleTimeoutReader calls the function of timer if no Read returns for timeout.
+type idleTimeoutReader struct {
+	r       io.Reader
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.timer.Reset(r.timeout)
+	return n, err
+}
+
+// capDuration returns value capped at limit. Zero values are no value and no limit.
+func capDuration(value, limit time.Duration) time.Duration {
+	if value <= 0 || (limit > 0 && value > limit) {
+		return limit
+	}
+	return value
+}
+
+// capInt64 returns value capped at limit. Zero values are no value and no limit.
+func capInt64(value, limit int64) int64 {
+	if value <= 0 || (limit > 0 && value > limit) {
+		return limit
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetcherHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer server.Close()
+
+	config := DefaultFetcherConfig()
+	config.AcceptLanguage = "en"
+	config.Headers = map[string]string{"X-Team": "parser", "X-Trace": "server"}
+	fetcher := NewFetcher(config)
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL, FetchOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("User-Agent") != DefaultUserAgent || got.Get("Accept-Language") != "en" || got.Get("X-Team") != "parser" {
+		t.Errorf("Unexpected headers %v", got)
+	}
+
+	options := FetchOptions{UserAgent: "Custom/1.0", AcceptLanguage: "tr", Headers: map[string]string{"X-Trace": "request"}}
+	if _, err := fetcher.Fetch(context.Background(), server.URL, options); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("User-Agent") != "Custom/1.0" || got.Get("Accept-Language") != "tr" || got.Get("X-Team") != "parser" || got.Get("X-Trace") != "request" {
+		t.Errorf("Unexpected headers %v", got)
+	}
+}
+
+func TestFetcherMaxBodyBytes(t *testing.T) {
+	body := strings.Repeat("a", 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// No Content-Length, the limit is checked while reading.
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	config := DefaultFetcherConfig()
+	config.MaxBodyBytes = 100
+	fetcher := NewFetcher(config)
+
+	tests := []struct {
+		path     string
+		options  FetchOptions
+		wantKind ErrorKind
+	}{
+		{path: "/", wantKind: KindUnknown},
+		{path: "/chunked", wantKind: KindUnknown},
+		// Requests can not raise the limit of the server.
+		{path: "/", options: FetchOptions{MaxBodyBytes: 1000}, wantKind: KindUnknown},
+		{path: "/", options: FetchOptions{MaxBodyBytes: 99}, wantKind: KindTooLarge},
+		{path: "/chunked", options: FetchOptions{MaxBodyBytes: 99}, wantKind: KindTooLarge},
+	}
+	for _, tt := range tests {
+		response, err := fetcher.Fetch(context.Background(), server.URL+tt.path, tt.options)
+		if KindOf(err) != tt.wantKind {
+			t.Errorf("%s %+v: expected %s, got %v", tt.path, tt.options, tt.wantKind, err)
+		}
+		if err == nil && string(response.Body) != body {
+			t.Errorf("%s %+v: unexpected body %q", tt.path, tt.options, response.Body)
+		}
+	}
+}
+
+func TestFetcherTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		w.Write([]byte("</html>"))
+	}))
+	defer server.Close()
+
+	config := DefaultFetcherConfig()
+	config.ReadTimeout = 50 * time.Millisecond
+	if _, err := NewFetcher(config).Fetch(context.Background(), server.URL, FetchOptions{}); KindOf(err) != KindTimeout {
+		t.Errorf("Expected a read timeout, got %v", err)
+	}
+
+	config = DefaultFetcherConfig()
+	config.TotalTimeout = 50 * time.Millisecond
+	// Requests can not raise the timeout of the server.
+	if _, err := NewFetcher(config).Fetch(context.Background(), server.URL, FetchOptions{Timeout: time.Minute}); KindOf(err) != KindTimeout {
+		t.Errorf("Expected a total timeout, got %v", err)
+	}
+
+	config = DefaultFetcherConfig()
+	if _, err := NewFetcher(config).Fetch(context.Background(), server.URL, FetchOptions{Timeout: 50 * time.Millisecond}); KindOf(err) != KindTimeout {
+		t.Errorf("Expected a request timeout, got %v", err)
+	}
+
+	// Requests can lower the read timeout of the server, but not raise it.
+	if _, err := NewFetcher(config).Fetch(context.Background(), server.URL, FetchOptions{ReadTimeout: 50 * time.Millisecond}); KindOf(err) != KindTimeout {
+		t.Errorf("Expected a request read timeout, got %v", err)
+	}
+	config.ReadTimeout = 50 * time.Millisecond
+	if _, err := NewFetcher(config).Fetch(context.Background(), server.URL, FetchOptions{ReadTimeout: time.Minute}); KindOf(err) != KindTimeout {
+		t.Errorf("Expected a read timeout, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package extractor
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// getTitle returns the page title, or an empty string if the page has no title-related tags.
+func getTitle(document *goquery.Document) string {
+	// Get <Title> tag
+	title := document.Find("title").Text()
+
+	// Get all titles tagged with <h1>
+	titlesH1 := make([]string, 0)
+	document.Find("h1").Each(func(index int, item *goquery.Selection) {
+		titlesH1 = append(titlesH1, item.Text())
+	})
+
+	// Get all titles tagged with <h2>
+	titlesH2 := make([]string, 0)
+	document.Find("h2").Each(func(index int, item *goquery.Selection) {
+		titlesH2 = append(titlesH2, item.Text())
+	})
+
+	// Get all titles tagged with <h3>
+	titlesH3 := make([]string, 0)
+	document.Find("h3").Each(func(index int, item *goquery.Selection) {
+		titlesH3 = append(titlesH3, item.Text())
+	})
+
+	// Check whether URL contains <title>.
+	// If title is empty, get first <h1> title.
+	if title == "" {
+		if 0 < len(titlesH1) {
+			title = titlesH1[0]
+		}
+	}
+
+	// Check whether URL contains <title> and/or <h1>
+	// If title is empty, get first <h2> title.
+	if title == "" {
+		if 0 < len(titlesH2) {
+			title = titlesH2[0]
+		}
+	}
+
+	// Check whether URL contains <title>, <h1> and/or <h2>
+	// If title is empty, get first <h3> title.
+	if title == "" {
+		if 0 < len(titlesH3) {
+			title = titlesH3[0]
+		}
+	}
+
+	return title
+}
+
+// genericContent are the elements the generic content is made of. <h1> is left out, it is the title.
+const genericContent = "p, ol, ul, h2, h3, h4, h5, h6, blockquote, pre, table"
+
+// getContent returns the text content of the page and the nodes it was taken from, in document
+// order, or an empty string if it can not be parsed.
+func getContent(document *goquery.Document) (string, []*html.Node) {
+	// Content parser for general usage, the site specific ones are in the Registry.
+	// Take the paragraphs, lists, headings, ... of the body. The elements nested in another one
+	// are part of it.
+	var nodes []*html.Node
+	document.Find("body").Find(genericContent).Each(func(index int, item *goquery.Selection) {
+		if item.ParentsFiltered(genericContent).Length() != 0 {
+			return
+		}
+		if item.Is("p") && strings.Contains(item.Text(), "\n") {
+			return
+		}
+		nodes = append(nodes, item.Nodes...)
+	})
+
+	// The flat content is the text of the blocks, so both are in the same order.
+	content := blocksText(getNodesBlocks(nodes))
+	if content == "" {
+		return "", nil
+	}
+	return content, nodes
+}
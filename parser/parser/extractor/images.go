@@ -0,0 +1,299 @@
+package extractor
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Attributes holding the real source of lazy-loaded images, whose "src" is often a placeholder.
+var lazySrcAttrs = []string{"data-src", "data-lazy-src", "data-original", "data-url"}
+
+// File names of images which are not thumbnails (logos, sprites, tracking pixels, ...). The words
+// must stand alone in the path ("site-logo@2x.png", "/icons/") so that "silicon" or "blanket"
+// do not match.
+var unlikelyImages = regexp.MustCompile(`(?i)(?:^|[/_.-])(logo|sprite|pixel|avatar|icon|spacer|blank|badge|button|tracking)(?:[/_.@0-9-]|$)|/(ads?|avatars|badges|buttons|icons|logos|sprites)/`)
+
+// DefaultMaxImages is the number of image candidates returned when WithMaxImages is not used.
+const DefaultMaxImages = 5
+
+// The scores of the image ranking signals.
+const (
+	siteImageScore       = 100
+	ogImageScore         = 50
+	otherOgImageScore    = 30
+	twitterImageScore    = 30
+	jsonLDImageScore     = 40
+	largeImageScore      = 20
+	smallImageScore      = -50
+	aspectRatioScore     = 5
+	extremeRatioScore    = -20
+	mainContentScore     = 15
+	figureScore          = 10
+	boilerplateScore     = -15
+	unlikelyNameScore    = -40
+	maxAltScore          = 10
+	largeImageArea       = 300 * 200
+	minImageSide         = 50
+	maxImageAspectRatio  = 3.0
+	goodImageAspectRatio = 2.5
+)
+
+// ImageCandidate is an image of the page ranked as a possible thumbnail.
+type ImageCandidate struct {
+	URL   string
+	Score float64
+	// Reasons explain the score, e.g. "og:image" or "too small (1x1)".
+	Reasons []string
+	// Width and Height come from the attributes of the <img>, 0 if unknown.
+	Width  int
+	Height int
+	Alt    string
+	// Probe is set if the image was probed (see WithImageProbing). Rejected tells whether the
+	// probe found it broken or too small.
+	Probe    *ImageProbe
+	Rejected bool
+}
+
+// imageRanker collects and scores the image candidates of a page. Candidates found several
+// times (e.g. the og:image is also an <img> of the article) add up their scores.
+type imageRanker struct {
+	baseURL    string
+	candidates []*ImageCandidate
+	byURL      map[string]*ImageCandidate
+	// The candidates whose <img> was already scored.
+	scored map[*ImageCandidate]bool
+}
+
+// rankImages returns the image candidates of the page, best first. Their score comes from the
+// site extractor, og:image, Twitter Card and JSON-LD images, then for the <img> elements from
+// their width/height, aspect ratio, position in the main content, file name and alt text.
+// Images with the same score keep their page order.
+func rankImages(document *goquery.Document, baseURL, siteImage string, og *OpenGraph, twitter *TwitterCard, data *StructuredData) []ImageCandidate {
+	r := &imageRanker{baseURL: baseURL, byURL: make(map[string]*ImageCandidate), scored: make(map[*ImageCandidate]bool)}
+	r.add(siteImage, siteImageScore, "site extractor")
+	for i, image := range og.Images {
+		if i == 0 {
+			r.add(image, ogImageScore, "og:image")
+		} else {
+			r.add(image, otherOgImageScore, "secondary og:image")
+		}
+	}
+	r.add(twitter.Image, twitterImageScore, "twitter:image")
+	for _, image := range data.Images {
+		r.add(image, jsonLDImageScore, "json-ld image")
+	}
+	document.Find("body img").Each(func(index int, image *goquery.Selection) {
+		r.addImage(image)
+	})
+
+	candidates := make([]ImageCandidate, 0, len(r.candidates))
+	for _, candidate := range r.candidates {
+		candidates = append(candidates, *candidate)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// add scores the image at src, reason is not recorded if empty. data: URIs and empty sources
+// are ignored.
+func (r *imageRanker) add(src string, score float64, reason string) *ImageCandidate {
+	if src == "" || isDataURI(src) {
+		return nil
+	}
+	src = resolveURL(r.baseURL, src)
+	candidate, ok := r.byURL[src]
+	if !ok {
+		candidate = &ImageCandidate{URL: src}
+		path := src
+		if parsed, err := url.Parse(src); err == nil {
+			path = parsed.Path
+		}
+		if match := unlikelyImages.FindStringSubmatch(path); match != nil {
+			candidate.Score += unlikelyNameScore
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("file name looks like a %s", strings.ToLower(match[1]+match[2])))
+		}
+		r.byURL[src] = candidate
+		r.candidates = append(r.candidates, candidate)
+	}
+	candidate.Score += score
+	if reason != "" {
+		candidate.Reasons = append(candidate.Reasons, reason)
+	}
+	return candidate
+}
+
+// addImage scores an <img> of the page.
+func (r *imageRanker) addImage(image *goquery.Selection) {
+	candidate := r.add(imageSource(image), 0, "")
+	if candidate == nil || r.scored[candidate] {
+		// The other <img> of the same image are not new signals.
+		return
+	}
+	r.scored[candidate] = true
+	score := func(value float64, reason string) {
+		candidate.Score += value
+		candidate.Reasons = append(candidate.Reasons, reason)
+	}
+
+	candidate.Width, candidate.Height = imageDimension(image, "width"), imageDimension(image, "height")
+	if width, height := candidate.Width, candidate.Height; width != 0 && height != 0 {
+		size := fmt.Sprintf("%dx%d", width, height)
+		ratio := float64(width) / float64(height)
+		if ratio < 1 {
+			ratio = 1 / ratio
+		}
+		switch area := width * height; {
+		case width < minImageSide || height < minImageSide:
+			score(smallImageScore, "too small ("+size+")")
+		case area >= largeImageArea:
+			score(largeImageScore, "large ("+size+")")
+		default:
+			score(largeImageScore*float64(area)/largeImageArea, "medium ("+size+")")
+		}
+		if ratio > maxImageAspectRatio {
+			score(extremeRatioScore, "extreme aspect ratio")
+		} else if ratio <= goodImageAspectRatio && width >= minImageSide && height >= minImageSide {
+			score(aspectRatioScore, "good aspect ratio")
+		}
+	}
+
+	if image.Closest("header, footer, nav, aside").Length() != 0 {
+		score(boilerplateScore, "outside the main content")
+	} else if image.Closest(`article, main, [role="main"], [itemprop="articleBody"]`).Length() != 0 {
+		score(mainContentScore, "in the main content")
+	}
+	if image.Closest("figure").Length() != 0 {
+		score(figureScore, "in a figure")
+	}
+
+	candidate.Alt = strings.TrimSpace(image.AttrOr("alt", ""))
+	if candidate.Alt != "" {
+		score(math.Min(float64(len(candidate.Alt))/5, maxAltScore), "alt text")
+	}
+}
+
+// imageDimension returns the width or height attribute of an <img> in pixels, 0 if it is
+// missing or relative ("100%").
+func imageDimension(image *goquery.Selection, attr string) int {
+	value := strings.TrimSuffix(strings.TrimSpace(image.AttrOr(attr, "")), "px")
+	dimension, err := strconv.Atoi(value)
+	if err != nil || dimension < 0 {
+		return 0
+	}
+	return dimension
+}
+
+// getBaseURL returns the URL the relative URLs of the page are resolved against: the <base href>
+// of the page resolved against baseURL, or baseURL itself.
+func getBaseURL(document *goquery.Document, baseURL string) string {
+	href := strings.TrimSpace(document.Find("base[href]").First().AttrOr("href", ""))
+	if href == "" {
+		return baseURL
+	}
+	return resolveURL(baseURL, href)
+}
+
+// imageSource returns the source of an <img>: the largest candidate of its srcset (or of the
+// <source> elements of its <picture>), then its lazy-loading attributes, then its src.
+// data: URIs (lazy-loading placeholders) are skipped. It returns an empty string if none is found.
+func imageSource(image *goquery.Selection) string {
+	srcsets := []string{image.AttrOr("srcset", ""), image.AttrOr("data-srcset", "")}
+	if picture := image.Parent(); picture.Is("picture") {
+		picture.ChildrenFiltered("source").Each(func(index int, source *goquery.Selection) {
+			srcsets = append(srcsets, source.AttrOr("srcset", ""), source.AttrOr("data-srcset", ""))
+		})
+	}
+	if src := largestSrcsetCandidate(srcsets...); src != "" {
+		return src
+	}
+
+	for _, attr := range append(lazySrcAttrs, "src") {
+		if src := strings.TrimSpace(image.AttrOr(attr, "")); src != "" && !isDataURI(src) {
+			return src
+		}
+	}
+	return ""
+}
+
+// largestSrcsetCandidate returns the largest image candidate of the srcset attributes. Width
+// descriptors ("800w") win over pixel density ones ("2x"); candidates without descriptor are "1x".
+func largestSrcsetCandidate(srcsets ...string) string {
+	best, bestWidth, bestDensity := "", 0.0, 0.0
+	for _, srcset := range srcsets {
+		for _, fields := range splitSrcset(srcset) {
+			if isDataURI(fields[0]) {
+				continue
+			}
+			width, density := 0.0, 1.0
+			if len(fields) > 1 {
+				descriptor := strings.ToLower(fields[1])
+				value, err := strconv.ParseFloat(descriptor[:len(descriptor)-1], 64)
+				if err != nil {
+					continue
+				}
+				switch descriptor[len(descriptor)-1] {
+				case 'w':
+					width = value
+				case 'x':
+					density = value
+				default:
+					continue
+				}
+			}
+			if width > bestWidth || (width == bestWidth && density > bestDensity) {
+				best, bestWidth, bestDensity = fields[0], width, density
+			}
+		}
+	}
+	return best
+}
+
+// splitSrcset returns the candidates of a srcset attribute: their URL then their descriptors.
+// As in the HTML parsing algorithm, a candidate ends after its descriptors, at a comma, and
+// commas inside a URL (e.g. "/w_400,h_300/a.jpg") are part of it; trailing commas end it.
+func splitSrcset(srcset string) [][]string {
+	var candidates [][]string
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r' }
+	for i := 0; i < len(srcset); {
+		if isSpace(srcset[i]) || srcset[i] == ',' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(srcset) && !isSpace(srcset[i]) {
+			i++
+		}
+		src := srcset[start:i]
+		if trimmed := strings.TrimRight(src, ","); trimmed != src {
+			candidates = append(candidates, []string{trimmed})
+			continue
+		}
+		// The descriptors end at the first comma outside of parentheses.
+		start, depth := i, 0
+		for ; i < len(srcset) && (srcset[i] != ',' || depth > 0); i++ {
+			switch srcset[i] {
+			case '(':
+				depth++
+			case ')':
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+		candidates = append(candidates, append([]string{src}, strings.Fields(srcset[start:i])...))
+	}
+	return candidates
+}
+
+func isDataURI(src string) bool {
+	return strings.HasPrefix(strings.ToLower(src), "data:")
+}
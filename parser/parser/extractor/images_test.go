@@ -0,0 +1,84 @@
+package extractor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestImageSource(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{html: `<img src="a.jpg">`, want: "a.jpg"},
+		{html: `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-src="lazy.jpg">`, want: "lazy.jpg"},
+		{html: `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-lazy-src="lazy.jpg">`, want: "lazy.jpg"},
+		{html: `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">`, want: ""},
+		{html: `<img src="a.jpg" srcset="a-1x.jpg, a-3x.jpg 3x, a-2x.jpg 2x">`, want: "a-3x.jpg"},
+		{html: `<img src="a.jpg" srcset="a-small.jpg 320w,a-big.jpg 1024w, a-2x.jpg 2x">`, want: "a-big.jpg"},
+		{html: `<img srcset="https://cdn.example.com/w_400,h_300/a.jpg 400w, https://cdn.example.com/w_1200,h_900/a.jpg 1200w">`, want: "https://cdn.example.com/w_1200,h_900/a.jpg"},
+		{html: `<img srcset="/c_fill,w_200/a.jpg 1x,/c_fill,w_400/a.jpg 2x">`, want: "/c_fill,w_400/a.jpg"},
+		{html: `<img srcset="a-1x.jpg,, a-2x.jpg 2x, data:image/png;base64,iVBOR,w0KGgo= 3x">`, want: "a-2x.jpg"},
+		{html: `<picture><source srcset="b-big.webp 2000w"><img src="a.jpg" srcset="a-big.jpg 1024w"></picture>`, want: "b-big.webp"},
+	}
+	for _, tt := range tests {
+		document, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := imageSource(document.Find("img")); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.html, tt.want, got)
+		}
+	}
+}
+
+func TestThumbnailResolvedAgainstFinalURL(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{html: `<html><body><img src="/static/img.png"></body></html>`, want: "https://example.com/static/img.png"},
+		{html: `<html><body><img src="3.jpg"></body></html>`, want: "https://example.com/news/3.jpg"},
+		{html: `<html><head><base href="https://cdn.example.org/assets/"></head><body><img src="3.jpg"></body></html>`, want: "https://cdn.example.org/assets/3.jpg"},
+		{html: `<html><head><base href="/assets/"></head><body><img src="3.jpg"></body></html>`, want: "https://example.com/assets/3.jpg"},
+	}
+	for _, tt := range tests {
+		result, err := New().ExtractFromReader(context.Background(), strings.NewReader(tt.html), "https://example.com/news/article.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ThumbnailURL != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.html, tt.want, result.ThumbnailURL)
+		}
+	}
+}
+
+func TestUnlikelyImages(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/img/site-logo@2x.png", want: "logo"},
+		{path: "/icons/share.svg", want: "icons"},
+		{path: "/static/icon32.png", want: "icon"},
+		{path: "/t/pixel.gif", want: "pixel"},
+		{path: "/ads/banner.jpg", want: "ads"},
+		{path: "/2024/silicon-valley.jpg", want: ""},
+		{path: "/lexicon.png", want: ""},
+		{path: "/pixels-of-the-year.jpg", want: ""},
+		{path: "/blanket.jpg", want: ""},
+		{path: "/uploads/headline.jpg", want: ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if match := unlikelyImages.FindStringSubmatch(tt.path); match != nil {
+			got = match[1] + match[2]
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.path, tt.want, got)
+		}
+	}
+}
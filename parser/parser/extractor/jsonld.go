@@ -0,0 +1,187 @@
+package extractor
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// schema.org types describing an article. The first object of one of these types is
+// used as the StructuredData of the page.
+var articleTypes = map[string]bool{
+	"Article":                  true,
+	"NewsArticle":              true,
+	"BlogPosting":              true,
+	"ReportageNews":            true,
+	"AnalysisNewsArticle":      true,
+	"OpinionNewsArticle":       true,
+	"ReviewNewsArticle":        true,
+	"BackgroundNewsArticle":    true,
+	"TechArticle":              true,
+	"ScholarlyArticle":         true,
+	"SocialMediaPosting":       true,
+	"LiveBlogPosting":          true,
+	"DiscussionForumPosting":   true,
+	"AdvertiserContentArticle": true,
+	"SatiricalArticle":         true,
+	"Report":                   true,
+}
+
+// StructuredData holds the schema.org article found in the JSON-LD blocks of a page.
+type StructuredData struct {
+	// Type is the schema.org @type of the article (NewsArticle, BlogPosting, ...).
+	Type          string
+	Headline      string
+	Description   string
+	Authors       []string
+	DatePublished time.Time
+	DateModified  time.Time
+	Images        []string
+	ArticleBody   string
+	Publisher     string
+	Keywords      []string
+	URL           string
+}
+
+// getJSONLD returns the raw content of every valid <script type="application/ld+json"> block
+// of the page and the first article found in them (nil if there is none).
+// Relative URLs are resolved against baseURL.
+func getJSONLD(document *goquery.Document, baseURL string) ([]string, *StructuredData) {
+	var blocks []string
+	var article map[string]interface{}
+	document.Find(`script[type="application/ld+json"]`).Each(func(index int, item *goquery.Selection) {
+		raw := strings.TrimSpace(item.Text())
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			// Invalid blocks are common on real pages, they are skipped.
+			return
+		}
+		blocks = append(blocks, raw)
+		if article == nil {
+			article = findArticle(value)
+		}
+	})
+	if article == nil {
+		return blocks, nil
+	}
+
+	data := &StructuredData{
+		Type:          articleType(article),
+		Headline:      firstNonEmpty(jsonString(article["headline"]), jsonString(article["name"])),
+		Description:   jsonString(article["description"]),
+		Authors:       jsonNames(article["author"]),
+		DatePublished: parseDate(jsonString(article["datePublished"])),
+		DateModified:  parseDate(jsonString(article["dateModified"])),
+		ArticleBody:   strings.TrimSpace(jsonString(article["articleBody"])),
+		URL:           resolveURL(baseURL, firstNonEmpty(jsonString(article["url"]), jsonID(article["mainEntityOfPage"]))),
+	}
+	if publishers := jsonNames(article["publisher"]); len(publishers) != 0 {
+		data.Publisher = publishers[0]
+	}
+	for _, image := range jsonURLs(article["image"]) {
+		data.Images = append(data.Images, resolveURL(baseURL, image))
+	}
+	// keywords is either a comma separated string or a list of strings.
+	for _, value := range jsonList(article["keywords"]) {
+		for _, keyword := range strings.Split(jsonString(value), ",") {
+			keyword = strings.TrimSpace(keyword)
+			if keyword != "" && !contains(data.Keywords, keyword) {
+				data.Keywords = append(data.Keywords, keyword)
+			}
+		}
+	}
+	return blocks, data
+}
+
+// findArticle walks a decoded JSON-LD value (object, list or @graph) and returns the first
+// object whose @type is an article type.
+func findArticle(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if article := findArticle(item); article != nil {
+				return article
+			}
+		}
+	case map[string]interface{}:
+		if articleType(v) != "" {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findArticle(graph)
+		}
+	}
+	return nil
+}
+
+// articleType returns the first article @type of the object, or an empty string.
+func articleType(object map[string]interface{}) string {
+	for _, t := range jsonList(object["@type"]) {
+		if name := jsonString(t); articleTypes[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// jsonList returns value as a list; a single value becomes a list of one element.
+func jsonList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	}
+	return []interface{}{value}
+}
+
+// jsonString returns value if it is a string, or an empty string.
+func jsonString(value interface{}) string {
+	s, _ := value.(string)
+	return strings.TrimSpace(s)
+}
+
+// jsonNames returns the names of a Person/Organization value, which can be a plain string,
+// an object with a "name" or a list of them.
+func jsonNames(value interface{}) []string {
+	var names []string
+	for _, item := range jsonList(value) {
+		name := jsonString(item)
+		if object, ok := item.(map[string]interface{}); ok {
+			name = jsonString(object["name"])
+		}
+		if name != "" && !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonID returns the @id of a value which is either a plain string or an object.
+func jsonID(value interface{}) string {
+	if object, ok := value.(map[string]interface{}); ok {
+		return jsonString(object["@id"])
+	}
+	return jsonString(value)
+}
+
+// jsonURL returns the URL of a value which is either a plain string or an object with an "url".
+func jsonURL(value interface{}) string {
+	if object, ok := value.(map[string]interface{}); ok {
+		return firstNonEmpty(jsonString(object["url"]), jsonString(object["contentUrl"]))
+	}
+	return jsonString(value)
+}
+
+// jsonURLs returns the URLs of an ImageObject value, which can be a plain string, an object or a list of them.
+func jsonURLs(value interface{}) []string {
+	var urls []string
+	for _, item := range jsonList(value) {
+		if u := jsonURL(item); u != "" && !contains(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,297 @@
+package extractor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+var (
+	whitespaces = regexp.MustCompile(`\s+`)
+	// The whitespace of inline Markdown, the line breaks of <br> excluded.
+	inlineSpaces = regexp.MustCompile(`[^\S\n]*\n[^\S\n]*|[^\S\n]+`)
+	// The characters escaped in text. <, > and & are written as entities so that text which
+	// looks like HTML stays text once the Markdown is rendered.
+	markdownSpecial = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`,
+		`<`, `&lt;`, `>`, `&gt;`, `&`, `&amp;`)
+	// The line starts which would make a heading, a quote, a list or a thematic break.
+	markdownBlockStart = regexp.MustCompile(`(?m)^ *([#>+=-]|\d+[.)])`)
+)
+
+// markdownRenderer renders content nodes as Markdown.
+type markdownRenderer struct {
+	baseURL string
+}
+
+// renderMarkdown renders the content nodes as Markdown. Relative links and images are resolved
+// against baseURL, the ones which are not http(s) are dropped.
+func renderMarkdown(nodes []*html.Node, baseURL string) string {
+	r := &markdownRenderer{baseURL: baseURL}
+	return strings.Join(r.blocks(nodes), "\n\n")
+}
+
+// blocks renders nodes as Markdown blocks. Consecutive inline nodes make a paragraph.
+func (r *markdownRenderer) blocks(nodes []*html.Node) []string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(inline.String()); text != "" {
+			out = append(out, escapeBlockStart(text))
+		}
+		inline.Reset()
+	}
+
+	for _, node := range nodes {
+		if isInline(node) {
+			inline.WriteString(r.inline(node))
+			continue
+		}
+		if node.Type != html.ElementNode || skippedElements[node.Data] {
+			continue
+		}
+
+		flush()
+		switch node.Data {
+		case "p":
+			if text := r.inlineChildren(node); text != "" {
+				out = append(out, escapeBlockStart(text))
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if text := oneLine(r.inlineChildren(node)); text != "" {
+				out = append(out, strings.Repeat("#", int(node.Data[1]-'0'))+" "+text)
+			}
+		case "ul", "ol":
+			if list := r.list(node); list != "" {
+				out = append(out, list)
+			}
+		case "blockquote":
+			if quote := strings.Join(r.blocks(childNodes(node)), "\n\n"); quote != "" {
+				out = append(out, prefixLines(quote, "> ", ">"))
+			}
+		case "pre":
+			code := strings.Trim(nodeText(node), "\n")
+			if strings.TrimSpace(code) != "" {
+				fence := "```"
+				if strings.Contains(code, fence) {
+					fence = "~~~"
+				}
+				out = append(out, fence+"\n"+code+"\n"+fence)
+			}
+		case "table":
+			if table := r.table(node); table != "" {
+				out = append(out, table)
+			}
+		case "hr":
+			out = append(out, "---")
+		default:
+			// Containers (div, section, article, figure, ...).
+			out = append(out, r.blocks(childNodes(node))...)
+		}
+	}
+	flush()
+	return out
+}
+
+// inline renders an inline node (text, link, emphasis, image, ...).
+func (r *markdownRenderer) inline(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return markdownSpecial.Replace(whitespaces.ReplaceAllString(node.Data, " "))
+	}
+	if node.Type != html.ElementNode || skippedElements[node.Data] {
+		return ""
+	}
+
+	switch node.Data {
+	case "br":
+		return "\\\n"
+	case "img":
+		src := safeURL(r.baseURL, nodeImageSource(node), false)
+		if src == "" {
+			return ""
+		}
+		return "![" + markdownSpecial.Replace(getAttr(node, "alt")) + "](" + src + ")"
+	case "code", "kbd", "samp", "tt":
+		code := whitespaces.ReplaceAllString(nodeText(node), " ")
+		if strings.TrimSpace(code) == "" {
+			return code
+		}
+		return "`" + strings.Replace(code, "`", "'", -1) + "`"
+	}
+
+	text := r.inlineChildren(node)
+	if text == "" {
+		return ""
+	}
+	switch node.Data {
+	case "a":
+		if href := safeURL(r.baseURL, getAttr(node, "href"), true); href != "" {
+			return "[" + text + "](" + href + ")"
+		}
+	case "strong", "b":
+		return "**" + text + "**"
+	case "em", "i":
+		return "*" + text + "*"
+	case "s", "strike":
+		return "~~" + text + "~~"
+	}
+	return text
+}
+
+// inlineChildren renders the children of node as one line of inline Markdown.
+func (r *markdownRenderer) inlineChildren(node *html.Node) string {
+	var sb strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if isInline(child) {
+			sb.WriteString(r.inline(child))
+		} else if child.Type == html.ElementNode && !skippedElements[child.Data] {
+			// Block elements misplaced in inline ones (e.g. <div> in <a>).
+			sb.WriteString(" " + r.inlineChildren(child) + " ")
+		}
+	}
+	return strings.TrimSpace(inlineSpaces.ReplaceAllStringFunc(sb.String(), func(space string) string {
+		if strings.Contains(space, "\n") {
+			return "\n"
+		}
+		return " "
+	}))
+}
+
+// list renders an <ul> or <ol>, nested lists included.
+func (r *markdownRenderer) list(node *html.Node) string {
+	var items []string
+	for li := node.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		marker := "- "
+		if node.Data == "ol" {
+			marker = strconv.Itoa(len(items)+1) + ". "
+		}
+		text := strings.Join(r.blocks(childNodes(li)), "\n")
+		items = append(items, marker+prefixLines(text, strings.Repeat(" ", len(marker)), "")[len(marker):])
+	}
+	return strings.Join(items, "\n")
+}
+
+// table renders a <table> as a GitHub flavored Markdown table, the first row being the header.
+func (r *markdownRenderer) table(node *html.Node) string {
+	var rows [][]string
+	goquery.NewDocumentFromNode(node).Find("tr").Each(func(index int, row *goquery.Selection) {
+		// Rows of nested tables belong to the nested table.
+		if row.Closest("table").Get(0) != node {
+			return
+		}
+		var cells []string
+		row.ChildrenFiltered("th, td").Each(func(index int, cell *goquery.Selection) {
+			cells = append(cells, strings.Replace(oneLine(r.inlineChildren(cell.Get(0))), "|", `\|`, -1))
+		})
+		if len(cells) != 0 {
+			rows = append(rows, cells)
+		}
+	})
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	var lines []string
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeBlockStart escapes the start of the lines of a paragraph which would otherwise be read
+// as another block (e.g. "# Heading?" or "1. not a list").
+func escapeBlockStart(text string) string {
+	return markdownBlockStart.ReplaceAllStringFunc(text, func(start string) string {
+		last := len(start) - 1
+		return start[:last] + `\` + start[last:]
+	})
+}
+
+// oneLine replaces the line breaks of inline Markdown with spaces, for headings and table cells.
+func oneLine(text string) string {
+	return strings.Replace(text, "\\\n", " ", -1)
+}
+
+// isInline reports whether node flows inside a paragraph.
+func isInline(node *html.Node) bool {
+	return node.Type == html.TextNode || (node.Type == html.ElementNode && inlineElements[node.Data])
+}
+
+// childNodes returns the children of node.
+func childNodes(node *html.Node) []*html.Node {
+	var children []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, child)
+	}
+	return children
+}
+
+// nodeText returns the text of node and its descendants, whitespace kept.
+func nodeText(node *html.Node) string {
+	return goquery.NewDocumentFromNode(node).Text()
+}
+
+// getAttr returns the value of the attribute key of node, or an empty string.
+func getAttr(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
+
+// nodeImageSource returns the source of the <img> node, lazy-loaded and srcset ones included.
+func nodeImageSource(node *html.Node) string {
+	return imageSource(goquery.NewDocumentFromNode(node).Selection)
+}
+
+// safeURL resolves ref against base and returns it if it is an http(s) URL (or a mailto: one
+// if allowMailto is set). It returns an empty string otherwise (javascript:, data:, ...).
+func safeURL(base, ref string, allowMailto bool) string {
+	if ref == "" {
+		return ""
+	}
+	resolved := resolveURL(base, ref)
+	lower := strings.ToLower(resolved)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"):
+		return resolved
+	case allowMailto && strings.HasPrefix(lower, "mailto:"):
+		return resolved
+	case base == "" && !strings.Contains(strings.SplitN(lower, "/", 2)[0], ":"):
+		// Relative URLs of pages without a base URL (e.g. local files) are kept as they are.
+		return resolved
+	}
+	return ""
+}
+
+// prefixLines prefixes every line of text, empty lines with emptyPrefix.
+func prefixLines(text, prefix, emptyPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
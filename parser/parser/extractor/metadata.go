@@ -0,0 +1,141 @@
+package extractor
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Layouts tried (in order) when parsing dates found in the page.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.000Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123,
+	time.RFC1123Z,
+}
+
+// getMetadata fills the page-level metadata of result (description, authors, dates, ...)
+// from the <head> of the document. Relative URLs are resolved against baseURL.
+func getMetadata(document *goquery.Document, baseURL string, result *Result) {
+	result.Description = getMetaContent(document, "name", "description")
+	result.SiteName = getMetaContent(document, "property", "og:site_name")
+	if result.SiteName == "" {
+		result.SiteName = getMetaContent(document, "name", "application-name")
+	}
+
+	// Authors can be given several times, keep them in page order without duplicates.
+	document.Find(`meta[name="author"], meta[property="article:author"]`).Each(func(index int, item *goquery.Selection) {
+		author := strings.TrimSpace(item.AttrOr("content", ""))
+		if author != "" && !contains(result.Authors, author) {
+			result.Authors = append(result.Authors, author)
+		}
+	})
+
+	result.PublishedTime = parseDate(getMetaContent(document, "property", "article:published_time"))
+	if result.PublishedTime.IsZero() {
+		result.PublishedTime = parseDate(getMetaContent(document, "name", "date"))
+	}
+	if result.PublishedTime.IsZero() {
+		result.PublishedTime = parseDate(document.Find("time[datetime]").First().AttrOr("datetime", ""))
+	}
+	result.ModifiedTime = parseDate(getMetaContent(document, "property", "article:modified_time"))
+
+	result.Language = strings.TrimSpace(document.Find("html").AttrOr("lang", ""))
+	if result.Language == "" {
+		result.Language = getMetaContent(document, "http-equiv", "content-language")
+	}
+
+	for _, name := range []string{"keywords", "news_keywords"} {
+		for _, keyword := range strings.Split(getMetaContent(document, "name", name), ",") {
+			keyword = strings.TrimSpace(keyword)
+			if keyword != "" && !contains(result.Keywords, keyword) {
+				result.Keywords = append(result.Keywords, keyword)
+			}
+		}
+	}
+
+	getDiscoveredLinks(document, baseURL, result)
+
+	// "icon", "shortcut icon", "apple-touch-icon", ... The first one wins.
+	document.Find("link[rel][href]").EachWithBreak(func(index int, item *goquery.Selection) bool {
+		if hasRel(item, "icon") || hasRel(item, "apple-touch-icon") {
+			result.FaviconURL = resolveURL(baseURL, item.AttrOr("href", ""))
+			return false
+		}
+		return true
+	})
+}
+
+// getMetaContent returns the trimmed "content" of the first <meta> whose attr equals value.
+func getMetaContent(document *goquery.Document, attr, value string) string {
+	content := ""
+	document.Find("meta[" + attr + "]").EachWithBreak(func(index int, item *goquery.Selection) bool {
+		if strings.EqualFold(item.AttrOr(attr, ""), value) {
+			content = strings.TrimSpace(item.AttrOr("content", ""))
+			return content == ""
+		}
+		return true
+	})
+	return content
+}
+
+// parseDate parses the value with the first matching layout. It returns the zero time if none matches.
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// resolveURL resolves ref against base. If either of them can not be parsed, ref is returned unchanged.
+func resolveURL(base, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if base == "" || ref == "" {
+		return ref
+	}
+	baseUrl, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	refUrl, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseUrl.ResolveReference(refUrl).String()
+}
+
+// countWords returns the number of whitespace separated words in text.
+func countWords(text string) int {
+	return len(strings.Fields(text))
+}
+
+// firstNonEmpty returns the first non empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package extractor
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// OpenGraph holds the Open Graph (https://ogp.me) properties of a page.
+type OpenGraph struct {
+	Title       string
+	Type        string
+	URL         string
+	Description string
+	SiteName    string
+	Locale      string
+	// Images lists every og:image of the page, in page order. The first one is the main image.
+	Images []string
+	Video  string
+}
+
+// TwitterCard holds the Twitter Card (twitter:*) properties of a page.
+type TwitterCard struct {
+	Card        string
+	Site        string
+	Creator     string
+	Title       string
+	Description string
+	Image       string
+	ImageAlt    string
+}
+
+// Image returns the main og:image, or an empty string.
+func (og *OpenGraph) Image() string {
+	if og == nil || len(og.Images) == 0 {
+		return ""
+	}
+	return og.Images[0]
+}
+
+// getMetaProperties returns the content of every <meta property|name="prefix..."> tag,
+// keyed by the lower cased property name, in page order.
+func getMetaProperties(document *goquery.Document, prefix string) map[string][]string {
+	properties := make(map[string][]string)
+	document.Find("meta[property], meta[name]").Each(func(index int, item *goquery.Selection) {
+		name, exist := item.Attr("property")
+		if !exist || !strings.HasPrefix(strings.ToLower(name), prefix) {
+			name = item.AttrOr("name", "")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		content := strings.TrimSpace(item.AttrOr("content", ""))
+		if strings.HasPrefix(name, prefix) && content != "" {
+			properties[name] = append(properties[name], content)
+		}
+	})
+	return properties
+}
+
+// first returns the first value of the first key that has one.
+func first(properties map[string][]string, keys ...string) string {
+	for _, key := range keys {
+		if values := properties[key]; len(values) != 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// getOpenGraph returns the Open Graph properties of the page, or nil if it has none.
+// Relative URLs are resolved against baseURL.
+func getOpenGraph(document *goquery.Document, baseURL string) *OpenGraph {
+	properties := getMetaProperties(document, "og:")
+	if len(properties) == 0 {
+		return nil
+	}
+
+	og := &OpenGraph{
+		Title:       first(properties, "og:title"),
+		Type:        first(properties, "og:type"),
+		URL:         resolveURL(baseURL, first(properties, "og:url")),
+		Description: first(properties, "og:description"),
+		SiteName:    first(properties, "og:site_name"),
+		Locale:      first(properties, "og:locale"),
+		Video:       resolveURL(baseURL, first(properties, "og:video:secure_url", "og:video:url", "og:video")),
+	}
+	// og:image:secure_url and og:image:url are alternatives of the og:image before them,
+	// og:image is the one every page sets.
+	images := properties["og:image"]
+	if len(images) == 0 {
+		images = properties["og:image:secure_url"]
+	}
+	if len(images) == 0 {
+		images = properties["og:image:url"]
+	}
+	for _, image := range images {
+		image = resolveURL(baseURL, image)
+		if !contains(og.Images, image) {
+			og.Images = append(og.Images, image)
+		}
+	}
+	return og
+}
+
+// getTwitterCard returns the Twitter Card properties of the page, or nil if it has none.
+// Relative URLs are resolved against baseURL.
+func getTwitterCard(document *goquery.Document, baseURL string) *TwitterCard {
+	properties := getMetaProperties(document, "twitter:")
+	if len(properties) == 0 {
+		return nil
+	}
+
+	return &TwitterCard{
+		Card:        first(properties, "twitter:card"),
+		Site:        first(properties, "twitter:site"),
+		Creator:     first(properties, "twitter:creator"),
+		Title:       first(properties, "twitter:title"),
+		Description: first(properties, "twitter:description"),
+		Image:       resolveURL(baseURL, first(properties, "twitter:image", "twitter:image:src")),
+		ImageAlt:    first(properties, "twitter:image:alt"),
+	}
+}
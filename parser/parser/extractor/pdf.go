@@ -0,0 +1,684 @@
+package extractor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// The values of a PDF document. Numbers are float64, booleans bool and null nil.
+type (
+	pdfName    string
+	pdfString  []byte
+	pdfKeyword string
+	pdfArray   []interface{}
+	pdfDict    map[pdfName]interface{}
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		data []byte
+	}
+)
+
+const (
+	// maxPDFStreamBytes bounds the size of a decompressed stream.
+	maxPDFStreamBytes = 64 << 20
+	// maxPDFDepth bounds the nesting of the page tree and of the references.
+	maxPDFDepth = 32
+	// maxPDFPages bounds the number of pages read from the page tree.
+	maxPDFPages = 10000
+	// pdfKerningSpace is the TJ kerning (in thousandths of an em) from which a space is assumed.
+	pdfKerningSpace = -200
+)
+
+var (
+	pdfObjectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	errNotPDF       = errors.New("not a PDF document")
+	errEncryptedPDF = errors.New("encrypted PDF documents are not supported")
+	errPDFTooLarge  = errors.New("decompressed PDF streams exceed the size limit")
+)
+
+// pdfDocument holds the objects of a PDF file, found by scanning it (so files with a broken
+// cross-reference table still work).
+type pdfDocument struct {
+	objects map[int]interface{}
+	trailer pdfDict
+
+	// decoded caches the decoded streams, so every stream is decompressed at most once.
+	decoded map[*pdfStream][]byte
+	// used are the streams whose content was already read by a page.
+	used map[*pdfStream]bool
+	// budget is the number of decompressed bytes left for the document, unlimited if negative.
+	// err is set to errPDFTooLarge once it runs out.
+	budget int64
+	err    error
+}
+
+// pdfInfo is what readPDF found in a PDF file.
+type pdfInfo struct {
+	Title, Author, Subject, Keywords string
+	Created, Modified                time.Time
+	PageCount                        int
+	// Pages are the texts of the pages, lines separated by "\n".
+	Pages []string
+}
+
+// readPDF returns the info dictionary, page count and text of a PDF document. maxBytes bounds
+// the decompressed streams of the whole document (0 for no limit), the reading fails with
+// errPDFTooLarge beyond it. The context is checked while the objects are read and between the
+// pages.
+func readPDF(ctx context.Context, data []byte, maxBytes int64) (*pdfInfo, error) {
+	doc, err := parsePDF(ctx, data, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if doc.trailer["Encrypt"] != nil {
+		return nil, errEncryptedPDF
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info := &pdfInfo{}
+	if dict, ok := doc.resolve(doc.trailer["Info"]).(pdfDict); ok {
+		info.Title = doc.text(dict["Title"])
+		info.Author = doc.text(dict["Author"])
+		info.Subject = doc.text(dict["Subject"])
+		info.Keywords = doc.text(dict["Keywords"])
+		info.Created = parsePDFDate(doc.text(dict["CreationDate"]))
+		info.Modified = parsePDFDate(doc.text(dict["ModDate"]))
+	}
+
+	root, _ := doc.resolve(doc.trailer["Root"]).(pdfDict)
+	var pages []pdfDict
+	doc.collectPages(root["Pages"], nil, &pages, make(map[int]bool), 0)
+	info.PageCount = len(pages)
+	for _, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		text := doc.pageText(page)
+		if doc.err != nil {
+			return nil, doc.err
+		}
+		info.Pages = append(info.Pages, text)
+	}
+	return info, nil
+}
+
+func parsePDF(ctx context.Context, data []byte, maxBytes int64) (*pdfDocument, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\n\f\r "), []byte("%PDF-")) {
+		return nil, errNotPDF
+	}
+
+	doc := &pdfDocument{
+		objects: make(map[int]interface{}),
+		trailer: pdfDict{},
+		decoded: make(map[*pdfStream][]byte),
+		used:    make(map[*pdfStream]bool),
+		budget:  -1,
+	}
+	if maxBytes > 0 {
+		doc.budget = maxBytes
+	}
+	// Objects of later revisions (incremental updates) replace the earlier ones.
+	// The headers before read are in the data of an object already read (e.g. a damaged string
+	// running over the next objects) and are skipped. The values are read up to the next
+	// "endobj", so that the data is read once whatever the damage.
+	var objectStreams []*pdfStream
+	read, objectEnd := 0, 0
+	for _, match := range pdfObjectHeader.FindAllSubmatchIndex(data, -1) {
+		if match[0] < read {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if objectEnd < match[1] {
+			objectEnd = len(data)
+			if i := bytes.Index(data[match[1]:], []byte("endobj")); i >= 0 {
+				objectEnd = match[1] + i
+			}
+		}
+		num, _ := strconv.Atoi(string(data[match[2]:match[3]]))
+		l := &pdfLexer{data: data, pos: match[1]}
+		value, err := l.object(objectEnd)
+		read = l.pos
+		if err != nil {
+			continue
+		}
+		doc.objects[num] = value
+		if stream, ok := value.(*pdfStream); ok {
+			switch stream.dict["Type"] {
+			case pdfName("ObjStm"):
+				objectStreams = append(objectStreams, stream)
+			case pdfName("XRef"):
+				doc.mergeTrailer(stream.dict)
+			}
+		}
+	}
+	for offset := 0; ; {
+		i := bytes.Index(data[offset:], []byte("trailer"))
+		if i < 0 {
+			break
+		}
+		offset += i + len("trailer")
+		l := &pdfLexer{data: data, pos: offset}
+		if dict, ok := l.value().(pdfDict); ok {
+			doc.mergeTrailer(dict)
+		}
+		offset = l.pos
+	}
+
+	for _, stream := range objectStreams {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		doc.loadObjectStream(stream)
+	}
+	if doc.err != nil {
+		return nil, doc.err
+	}
+	if doc.trailer["Root"] == nil {
+		return nil, errors.New("PDF document without catalog")
+	}
+	return doc, nil
+}
+
+// mergeTrailer keeps the entries of the last trailer having them.
+func (doc *pdfDocument) mergeTrailer(dict pdfDict) {
+	for _, key := range []pdfName{"Root", "Info", "Encrypt"} {
+		if value, ok := dict[key]; ok {
+			doc.trailer[key] = value
+		}
+	}
+}
+
+// loadObjectStream adds the objects compressed in an object stream. The objects defined
+// directly in the file are kept.
+func (doc *pdfDocument) loadObjectStream(stream *pdfStream) {
+	data, err := doc.decodeStream(stream)
+	if err != nil {
+		return
+	}
+	count, _ := doc.resolve(stream.dict["N"]).(float64)
+	first, _ := doc.resolve(stream.dict["First"]).(float64)
+	if first < 0 || first > float64(len(data)) {
+		return
+	}
+	header := &pdfLexer{data: data[:int(first)]}
+	var nums, offsets []int
+	for i := 0; i < int(count); i++ {
+		num, ok1 := header.value().(float64)
+		offset, ok2 := header.value().(float64)
+		if !ok1 || !ok2 {
+			break
+		}
+		// The offsets are relative to First, and compared as floats so that huge values do not
+		// overflow.
+		if offset < 0 || offset >= float64(len(data))-first {
+			continue
+		}
+		nums = append(nums, int(num))
+		offsets = append(offsets, int(first+offset))
+	}
+
+	// Each object is read up to the next one, and once, so that wrong offsets do not make the
+	// data read over and over.
+	ends := append([]int{len(data)}, offsets...)
+	sort.Ints(ends)
+	values := make(map[int]interface{})
+	for i, num := range nums {
+		if _, ok := doc.objects[num]; ok {
+			continue
+		}
+		offset := offsets[i]
+		value, ok := values[offset]
+		if !ok {
+			l := &pdfLexer{data: data[:ends[sort.SearchInts(ends, offset+1)]], pos: offset}
+			value = l.value()
+			values[offset] = value
+		}
+		doc.objects[num] = value
+	}
+}
+
+// resolve follows the references of value.
+func (doc *pdfDocument) resolve(value interface{}) interface{} {
+	for depth := 0; depth < maxPDFDepth; depth++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		value = doc.objects[ref.num]
+	}
+	return nil
+}
+
+// dict resolves value to a dictionary, the one of a stream included.
+func (doc *pdfDocument) dict(value interface{}) pdfDict {
+	switch value := doc.resolve(value).(type) {
+	case pdfDict:
+		return value
+	case *pdfStream:
+		return value.dict
+	}
+	return nil
+}
+
+// text decodes a text string (UTF-16BE with a byte order mark, UTF-8 with one, or PDFDocEncoding).
+func (doc *pdfDocument) text(value interface{}) string {
+	s, ok := doc.resolve(value).(pdfString)
+	if !ok {
+		return ""
+	}
+	switch {
+	case bytes.HasPrefix(s, []byte{0xfe, 0xff}):
+		return strings.TrimSpace(decodeUTF16BE(s[2:]))
+	case bytes.HasPrefix(s, []byte{0xef, 0xbb, 0xbf}):
+		return strings.TrimSpace(string(s[3:]))
+	}
+	return strings.TrimSpace(decodeWinAnsi(s))
+}
+
+// decodeStream returns the decoded data of a stream. Only FlateDecode is supported. The streams
+// are decoded once, and their decompressed bytes are taken from the budget of the document.
+func (doc *pdfDocument) decodeStream(stream *pdfStream) ([]byte, error) {
+	if data, ok := doc.decoded[stream]; ok {
+		return data, nil
+	}
+	if doc.err != nil {
+		return nil, doc.err
+	}
+	var filters []interface{}
+	switch filter := doc.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{filter}
+	case pdfArray:
+		filters = filter
+	}
+
+	data := stream.data
+	for _, filter := range filters {
+		switch doc.resolve(filter) {
+		case pdfName("FlateDecode"), pdfName("Fl"):
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+			limit := int64(maxPDFStreamBytes)
+			if doc.budget >= 0 && doc.budget < limit {
+				// One more byte to tell a stream of exactly the budget from a larger one.
+				limit = doc.budget + 1
+			}
+			decoded, err := ioutil.ReadAll(io.LimitReader(r, limit))
+			// Truncated streams are common, keep what could be decoded.
+			if err != nil && len(decoded) == 0 {
+				return nil, err
+			}
+			if !doc.spend(len(decoded)) {
+				return nil, doc.err
+			}
+			data = decoded
+		default:
+			return nil, fmt.Errorf("unsupported PDF filter %v", filter)
+		}
+	}
+	doc.decoded[stream] = data
+	return data, nil
+}
+
+// spend takes n decompressed bytes from the budget of the document. It returns false, and sets
+// errPDFTooLarge, if the budget runs out.
+func (doc *pdfDocument) spend(n int) bool {
+	if doc.budget < 0 {
+		return true
+	}
+	if int64(n) > doc.budget {
+		doc.budget, doc.err = 0, errPDFTooLarge
+		return false
+	}
+	doc.budget -= int64(n)
+	return true
+}
+
+// collectPages appends the pages of the page tree node to pages, in order, up to maxPDFPages.
+// The resources are inherited from the parent nodes. The nodes already visited are skipped, so
+// that cycles in the tree do not make it endless.
+func (doc *pdfDocument) collectPages(node interface{}, resources interface{}, pages *[]pdfDict, visited map[int]bool, depth int) {
+	if ref, ok := node.(pdfRef); ok {
+		if visited[ref.num] {
+			return
+		}
+		visited[ref.num] = true
+	}
+	dict := doc.dict(node)
+	if dict == nil || depth > maxPDFDepth || len(*pages) >= maxPDFPages {
+		return
+	}
+	if r, ok := dict["Resources"]; ok {
+		resources = r
+	}
+	if dict["Type"] == pdfName("Page") || dict["Kids"] == nil {
+		page := pdfDict{"Contents": dict["Contents"], "Resources": resources}
+		*pages = append(*pages, page)
+		return
+	}
+	kids, _ := doc.resolve(dict["Kids"]).(pdfArray)
+	for _, kid := range kids {
+		doc.collectPages(kid, resources, pages, visited, depth+1)
+	}
+}
+
+// pageText returns the text of a page, lines separated by "\n". It returns an empty string if
+// the budget of the document runs out.
+func (doc *pdfDocument) pageText(page pdfDict) string {
+	var content []byte
+	contents := doc.resolve(page["Contents"])
+	if array, ok := contents.(pdfArray); ok {
+		for _, part := range array {
+			if stream, ok := doc.resolve(part).(*pdfStream); ok {
+				if data, err := doc.contentStream(stream); err == nil {
+					content = append(append(content, data...), '\n')
+				}
+			}
+		}
+	} else if stream, ok := contents.(*pdfStream); ok {
+		content, _ = doc.contentStream(stream)
+	}
+	if doc.err != nil {
+		return ""
+	}
+
+	fonts := make(map[pdfName]*pdfFont)
+	for name, font := range doc.dict(doc.dict(page["Resources"])["Font"]) {
+		fonts[name] = doc.loadFont(doc.dict(font))
+	}
+	return interpretPDFText(content, fonts)
+}
+
+// contentStream returns the decoded data of a content stream. A stream is decoded once, but
+// the content streams used again (by several pages, or several times by a page) are taken from
+// the budget of the document every time, as their text is extracted every time.
+func (doc *pdfDocument) contentStream(stream *pdfStream) ([]byte, error) {
+	data, err := doc.decodeStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	if doc.used[stream] && !doc.spend(len(data)) {
+		return nil, doc.err
+	}
+	doc.used[stream] = true
+	return data, nil
+}
+
+// pdfFont decodes the strings shown with a font.
+type pdfFont struct {
+	// codeLength is the number of bytes of a character code.
+	codeLength int
+	// toUnicode maps the character codes to text. Without it, single byte codes are decoded as
+	// WinAnsiEncoding and multiple byte ones are dropped.
+	toUnicode map[string]string
+}
+
+func (doc *pdfDocument) loadFont(dict pdfDict) *pdfFont {
+	font := &pdfFont{codeLength: 1}
+	if dict["Subtype"] == pdfName("Type0") {
+		font.codeLength = 2
+	}
+	if stream, ok := doc.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := doc.decodeStream(stream); err == nil {
+			font.toUnicode, font.codeLength = parseToUnicode(data, font.codeLength)
+		}
+	}
+	return font
+}
+
+func (font *pdfFont) decode(s []byte) string {
+	if font == nil {
+		return decodeWinAnsi(s)
+	}
+	if font.toUnicode == nil {
+		if font.codeLength != 1 {
+			return ""
+		}
+		return decodeWinAnsi(s)
+	}
+	var sb strings.Builder
+	for i := 0; i+font.codeLength <= len(s); i += font.codeLength {
+		sb.WriteString(font.toUnicode[string(s[i:i+font.codeLength])])
+	}
+	return sb.String()
+}
+
+// parseToUnicode parses the bfchar and bfrange mappings of a ToUnicode CMap. It also returns
+// the code length of its code space range, codeLength if it has none.
+func parseToUnicode(data []byte, codeLength int) (map[string]string, int) {
+	mappings := make(map[string]string)
+	l := &pdfLexer{data: data}
+	var operands []interface{}
+	for !l.eof() {
+		value := l.value()
+		keyword, ok := value.(pdfKeyword)
+		if !ok {
+			operands = append(operands, value)
+			continue
+		}
+		switch keyword {
+		case "endcodespacerange":
+			if len(operands) >= 1 {
+				if low, ok := operands[0].(pdfString); ok && len(low) > 0 {
+					codeLength = len(low)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(pdfString)
+				dst, ok2 := operands[i+1].(pdfString)
+				if ok1 && ok2 {
+					mappings[string(src)] = decodeUTF16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, ok1 := operands[i].(pdfString)
+				high, ok2 := operands[i+1].(pdfString)
+				if !ok1 || !ok2 || len(low) != len(high) || len(low) == 0 {
+					continue
+				}
+				start, end := bytesToInt(low), bytesToInt(high)
+				if end < start || end-start > 0xffff {
+					continue
+				}
+				for code := start; code <= end; code++ {
+					var text string
+					switch dst := operands[i+2].(type) {
+					case pdfString:
+						// The last byte of the destination is incremented.
+						incremented := append([]byte(nil), dst...)
+						if len(incremented) > 0 {
+							incremented[len(incremented)-1] += byte(code - start)
+						}
+						text = decodeUTF16BE(incremented)
+					case pdfArray:
+						if code-start < len(dst) {
+							if s, ok := dst[code-start].(pdfString); ok {
+								text = decodeUTF16BE(s)
+							}
+						}
+					}
+					mappings[string(intToBytes(code, len(low)))] = text
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	return mappings, codeLength
+}
+
+// interpretPDFText runs the text operators of a content stream and returns the shown text.
+func interpretPDFText(content []byte, fonts map[pdfName]*pdfFont) string {
+	var sb strings.Builder
+	var font *pdfFont
+	newLine := func() {
+		if sb.Len() != 0 && !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	space := func() {
+		if s := sb.String(); s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+			sb.WriteString(" ")
+		}
+	}
+	show := func(value interface{}) {
+		if s, ok := value.(pdfString); ok {
+			sb.WriteString(font.decode(s))
+		}
+	}
+	number := func(value interface{}) float64 {
+		n, _ := value.(float64)
+		return n
+	}
+
+	l := &pdfLexer{data: content}
+	var operands []interface{}
+	for !l.eof() {
+		value := l.value()
+		operator, ok := value.(pdfKeyword)
+		if !ok {
+			operands = append(operands, value)
+			continue
+		}
+		switch operator {
+		case "Tf":
+			if len(operands) >= 1 {
+				name, _ := operands[0].(pdfName)
+				font = fonts[name]
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 && number(operands[1]) != 0 {
+				newLine()
+			} else {
+				space()
+			}
+		case "Tm", "T*", "ET":
+			newLine()
+		case "Tj":
+			if len(operands) >= 1 {
+				show(operands[0])
+			}
+		case "'", "\"":
+			newLine()
+			if len(operands) >= 1 {
+				show(operands[len(operands)-1])
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				array, _ := operands[0].(pdfArray)
+				for _, item := range array {
+					if n, ok := item.(float64); ok && n < pdfKerningSpace {
+						space()
+					}
+					show(item)
+				}
+			}
+		case "ID":
+			// Inline image data, up to the EI operator.
+			l.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+	return sb.String()
+}
+
+// parsePDFDate parses a PDF date ("D:YYYYMMDDHHmmSSOHH'mm'"), every part after the year being
+// optional. It returns the zero time if the date is invalid.
+func parsePDFDate(value string) time.Time {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "D:")
+	digits := len(value) - len(strings.TrimLeft(value, "0123456789"))
+	// A date has 4 (year) to 14 (second) digits.
+	const fullLayout = "20060102150405"
+	if digits < 4 || digits > len(fullLayout) {
+		return time.Time{}
+	}
+	// Default month and day are 01.
+	layout := fullLayout[:digits]
+	date := value[:digits]
+	if digits < 8 {
+		date += "0101"[digits-4:]
+		layout = "20060102"
+	}
+	location := time.UTC
+	if zone := strings.Replace(strings.TrimRight(value[digits:], "'"), "'", "", -1); len(zone) >= 3 && (zone[0] == '+' || zone[0] == '-') {
+		hours, _ := strconv.Atoi(zone[1:3])
+		minutes := 0
+		if len(zone) >= 5 {
+			minutes, _ = strconv.Atoi(zone[3:5])
+		}
+		offset := hours*3600 + minutes*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		location = time.FixedZone("", offset)
+	}
+	t, err := time.ParseInLocation(layout, date, location)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func decodeUTF16BE(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// The WinAnsiEncoding characters which differ from Latin-1.
+var winAnsiSpecials = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8a: 'Š', 0x8b: '‹', 0x8c: 'Œ', 0x8e: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜', 0x99: '™', 0x9a: 'š', 0x9b: '›',
+	0x9c: 'œ', 0x9e: 'ž', 0x9f: 'Ÿ',
+}
+
+func decodeWinAnsi(b []byte) string {
+	runes := make([]rune, 0, len(b))
+	for _, c := range b {
+		if r, ok := winAnsiSpecials[c]; ok {
+			runes = append(runes, r)
+		} else {
+			runes = append(runes, rune(c))
+		}
+	}
+	return string(runes)
+}
+
+func bytesToInt(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<8 | int(c)
+	}
+	return n
+}
+
+func intToBytes(n, length int) []byte {
+	b := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		b[i] = byte(n)
+		n >>= 8
+	}
+	return b
+}
@@ -0,0 +1,216 @@
+package extractor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildPDF returns a PDF file made of objects (numbered from 1) with a cross-reference table.
+func buildPDF(trailer string, objects ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n%s\nstartxref\n%d\n%%%%EOF\n", trailer, xref)
+	return buf.Bytes()
+}
+
+// flateStream returns a FlateDecode stream object of data.
+func flateStream(data string) string {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write([]byte(data))
+	w.Close()
+	return fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", buf.Len(), buf.String())
+}
+
+func testPDF() []byte {
+	return buildPDF("<< /Size 10 /Root 1 0 R /Info 2 0 R >>",
+		"<< /Type /Catalog /Pages 3 0 R >>",
+		// The title is UTF-16BE: "Rapor Özeti".
+		`<< /Title <FEFF005200610070006F0072002000D6007A006500740069> /Author (Jane \(J.\) Doe)
+/Subject (Quarterly figures) /Keywords (finance, report) /CreationDate (D:20200115103000+03'00') >>`,
+		"<< /Type /Pages /Kids [4 0 R 5 0 R] /Count 2 /Resources << /Font << /F1 6 0 R /F2 7 0 R >> >> >>",
+		"<< /Type /Page /Parent 3 0 R /Contents 8 0 R >>",
+		"<< /Type /Page /Parent 3 0 R /Contents [9 0 R] >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /Custom /Encoding /Identity-H /ToUnicode 10 0 R >>",
+		flateStream("BT /F1 12 Tf 72 720 Td (Annual report) Tj 0 -14 Td [(Sales gr) 20 (ew) -300 (by 5%)] TJ ET"),
+		flateStream("BT /F2 12 Tf 72 720 Td <00010002> Tj T* <0003> Tj ET"),
+		flateStream(`/CIDInit /ProcSet findresource begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+1 beginbfchar <0003> <00A7> endbfchar
+1 beginbfrange <0001> <0002> <0048> endbfrange
+endcmap`),
+	)
+}
+
+func TestReadPDF(t *testing.T) {
+	info, err := readPDF(context.Background(), testPDF(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Title != "Rapor Özeti" {
+		t.Errorf("unexpected title %q", info.Title)
+	}
+	if info.Author != "Jane (J.) Doe" || info.Subject != "Quarterly figures" || info.Keywords != "finance, report" {
+		t.Errorf("unexpected info %+v", info)
+	}
+	if want := time.Date(2020, 1, 15, 7, 30, 0, 0, time.UTC); !info.Created.Equal(want) {
+		t.Errorf("expected creation date %s, got %s", want, info.Created)
+	}
+	if info.PageCount != 2 {
+		t.Errorf("expected 2 pages, got %d", info.PageCount)
+	}
+	want := []string{"Annual report\nSales grew by 5%\n", "HI\n§\n"}
+	if !reflect.DeepEqual(info.Pages, want) {
+		t.Errorf("expected pages %q, got %q", want, info.Pages)
+	}
+}
+
+func TestReadPDFErrors(t *testing.T) {
+	if _, err := readPDF(context.Background(), []byte("<html></html>"), 0); err != errNotPDF {
+		t.Errorf("expected %v, got %v", errNotPDF, err)
+	}
+	encrypted := buildPDF("<< /Root 1 0 R /Encrypt 2 0 R >>",
+		"<< /Type /Catalog >>", "<< /Filter /Standard /V 2 >>")
+	if _, err := readPDF(context.Background(), encrypted, 0); err != errEncryptedPDF {
+		t.Errorf("expected %v, got %v", errEncryptedPDF, err)
+	}
+}
+
+func TestReadMalformedPDF(t *testing.T) {
+	catalog := "<< /Type /Catalog /Pages 2 0 R >>"
+	pages := "<< /Type /Pages /Kids [] /Count 0 >>"
+	tests := map[string][]byte{
+		"huge length":     buildPDF("<< /Root 1 0 R >>", catalog, pages, "<< /Length 1e30 >>\nstream\nabc\nendstream"),
+		"negative first":  buildPDF("<< /Root 1 0 R >>", catalog, pages, "<< /Type /ObjStm /N 1 /First -5 /Length 4 >>\nstream\n4 0 \nendstream"),
+		"negative offset": buildPDF("<< /Root 1 0 R >>", catalog, pages, "<< /Type /ObjStm /N 1 /First 5 /Length 8 >>\nstream\n4 -3 (x)\nendstream"),
+		"huge offset":     buildPDF("<< /Root 1 0 R >>", catalog, pages, "<< /Type /ObjStm /N 1 /First 9 /Length 12 >>\nstream\n4 1e30 (x)\nendstream"),
+		"nested arrays":   buildPDF("<< /Root 1 0 R >>", catalog, pages, strings.Repeat("[", 1<<20)),
+		"cyclic pages":    buildPDF("<< /Root 1 0 R >>", catalog, "<< /Type /Pages /Kids [2 0 R 2 0 R 2 0 R] >>"),
+	}
+	for name, data := range tests {
+		info, err := readPDF(context.Background(), data, 0)
+		if err != nil || info.PageCount != 0 {
+			t.Errorf("%s: expected an empty document, got %+v %v", name, info, err)
+		}
+	}
+
+	l := &pdfLexer{data: []byte(strings.Repeat("<< /A [", 1<<20))}
+	if _, err := l.object(len(l.data)); err != errPDFTooDeep {
+		t.Errorf("expected %v, got %v", errPDFTooDeep, err)
+	}
+}
+
+func TestReadPathologicalPDF(t *testing.T) {
+	catalog := "<< /Type /Catalog /Pages 2 0 R >>"
+	pages := "<< /Type /Pages /Kids [] /Count 0 >>"
+	tests := map[string][]byte{
+		"unterminated strings":   []byte("%PDF-1.4\n" + strings.Repeat("1 0 obj ((", 1<<16)),
+		"unterminated objects":   []byte("%PDF-1.4\n" + strings.Repeat("1 0 obj (( endobj\n", 1<<16)),
+		"unterminated trailers":  []byte("%PDF-1.4\n" + strings.Repeat("trailer (", 1<<16)),
+		"unterminated dicts":     []byte("%PDF-1.4\n" + strings.Repeat("1 0 obj << /A ", 1<<16)),
+		"streams without end":    []byte("%PDF-1.4\n" + strings.Repeat("1 0 obj << >> stream\n", 1<<16)),
+		"shared stream offsets":  buildPDF("<< /Root 1 0 R >>", catalog, pages, "<< /Type /ObjStm /N 65536 /First 262144 >>\nstream\n"+strings.Repeat("4 0 ", 1<<16)+strings.Repeat("(", 1<<16)+"\nendstream"),
+		"growing stream offsets": buildPDF("<< /Root 1 0 R >>", catalog, pages, "<< /Type /ObjStm /N 65536 /First 524288 >>\nstream\n"+strings.Repeat("4 0 ", 1<<17)+strings.Repeat("(", 1<<16)+"\nendstream"),
+	}
+	for name, data := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		if _, err := readPDF(ctx, data, 0); err == context.DeadlineExceeded {
+			t.Errorf("%s: expected a linear parsing, got %v", name, err)
+		}
+		cancel()
+	}
+
+	// A damaged object does not hide the next ones.
+	data := buildPDF("<< /Root 2 0 R >>", "<< /Title (unterminated >>", catalog, "<< /Type /Pages /Kids [4 0 R] /Count 1 >>", "<< /Type /Page >>")
+	if info, err := readPDF(context.Background(), data, 0); err != nil || info.PageCount != 1 {
+		t.Errorf("expected a page, got %+v %v", info, err)
+	}
+}
+
+func TestExtractPDF(t *testing.T) {
+	response := &FetchResponse{URL: "https://example.com/report.pdf", Body: testPDF()}
+	result, err := New().extractPDF(context.Background(), response, newExtractOptions([]ExtractOption{WithMarkdown()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Content != "Annual report Sales grew by 5% HI §" {
+		t.Errorf("unexpected content %q", result.Content)
+	}
+	if !reflect.DeepEqual(result.Authors, []string{"Jane (J.) Doe"}) || !reflect.DeepEqual(result.Keywords, []string{"finance", "report"}) {
+		t.Errorf("unexpected authors %q or keywords %q", result.Authors, result.Keywords)
+	}
+	if result.PageCount != 2 || result.Extractor != PDFExtractorName || len(result.Blocks) != 4 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if result.ContentMarkdown != "Annual report\n\nSales grew by 5%\n\nHI\n\n§" {
+		t.Errorf("unexpected markdown %q", result.ContentMarkdown)
+	}
+}
+
+func TestReadPDFLimits(t *testing.T) {
+	// A stream of 1 MB used 40 times by the page.
+	contents := strings.TrimSpace(strings.Repeat("4 0 R ", 40))
+	data := buildPDF("<< /Root 1 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents ["+contents+"] >>",
+		flateStream(strings.Repeat(" ", 1<<20)))
+	if _, err := readPDF(context.Background(), data, 10<<20); err != errPDFTooLarge {
+		t.Errorf("expected %v, got %v", errPDFTooLarge, err)
+	}
+	if _, err := readPDF(context.Background(), data, 50<<20); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := readPDF(ctx, testPDF(), 0); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestExtractPDFTooLarge(t *testing.T) {
+	response := &FetchResponse{URL: "https://example.com/report.pdf", Body: testPDF()}
+	options := newExtractOptions([]ExtractOption{WithFetchOptions(FetchOptions{MaxBodyBytes: 100})})
+	if _, err := New().extractPDF(context.Background(), response, options); KindOf(err) != KindTooLarge {
+		t.Errorf("expected %s, got %v", KindTooLarge, err)
+	}
+}
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "D:20200115103000Z", want: time.Date(2020, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{value: "D:20200115103000-05'30'", want: time.Date(2020, 1, 15, 16, 0, 0, 0, time.UTC)},
+		{value: "D:202003", want: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2021", want: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "D:2020011510300000000"},
+		{value: "yesterday"},
+		{value: ""},
+	}
+	for _, tt := range tests {
+		if got := parsePDFDate(tt.value); !got.Equal(tt.want) {
+			t.Errorf("%q: expected %s, got %s", tt.value, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package extractor
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// pdfLexer reads the values of the PDF syntax: of the file, object streams, content streams
+// and CMaps. Invalid bytes are skipped, so a damaged file yields what can still be read.
+type pdfLexer struct {
+	data []byte
+	pos  int
+	// depth is the number of arrays and dictionaries being read, err is set when it exceeds
+	// maxPDFDepth and the rest of the data is skipped.
+	depth int
+	err   error
+}
+
+var errPDFTooDeep = errors.New("PDF values nested too deep")
+
+// enter is called before reading an array or a dictionary, which is skipped when it returns
+// false. Otherwise leave must be called once it is read.
+func (l *pdfLexer) enter() bool {
+	if l.depth >= maxPDFDepth {
+		l.err, l.pos = errPDFTooDeep, len(l.data)
+		return false
+	}
+	l.depth++
+	return true
+}
+
+func (l *pdfLexer) leave() {
+	l.depth--
+}
+
+func (l *pdfLexer) eof() bool {
+	l.skipSpace()
+	return l.pos >= len(l.data)
+}
+
+func isPDFSpace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+// skipSpace skips the white space and the comments.
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token returns the regular characters from the current position.
+func (l *pdfLexer) token() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// object reads an indirect object after its "obj" header: a value, read up to limit at most,
+// and the data following it if it is the dictionary of a stream. The position is left after
+// the object, at the end of the data if it fails.
+func (l *pdfLexer) object(limit int) (interface{}, error) {
+	data := l.data
+	l.data = data[:limit]
+	value := l.value()
+	l.data = data
+	if l.err != nil {
+		return nil, l.err
+	}
+	dict, ok := value.(pdfDict)
+	if !ok {
+		return value, nil
+	}
+	l.skipSpace()
+	if !bytes.HasPrefix(l.data[l.pos:], []byte("stream")) {
+		return value, nil
+	}
+	l.pos += len("stream")
+	if bytes.HasPrefix(l.data[l.pos:], []byte("\r\n")) {
+		l.pos += 2
+	} else if l.pos < len(l.data) && (l.data[l.pos] == '\n' || l.data[l.pos] == '\r') {
+		l.pos++
+	}
+
+	// /Length is trusted when it ends at "endstream", which is searched otherwise (indirect or
+	// wrong lengths).
+	start := l.pos
+	// The length is compared as a float so that huge values do not overflow.
+	if length, ok := dict["Length"].(float64); ok && length >= 0 && length <= float64(len(l.data)-start) {
+		end := start + int(length)
+		after := bytes.TrimLeft(l.data[end:], "\x00\t\n\f\r ")
+		if bytes.HasPrefix(after, []byte("endstream")) {
+			l.pos = end
+			return &pdfStream{dict: dict, data: l.data[start:end]}, nil
+		}
+	}
+	end := bytes.Index(l.data[start:], []byte("endstream"))
+	if end < 0 {
+		l.pos = len(l.data)
+		return nil, errors.New("stream without endstream")
+	}
+	l.pos = start + end
+	return &pdfStream{dict: dict, data: bytes.TrimRight(l.data[start:l.pos], "\r\n")}, nil
+}
+
+// value reads the next value. Operators and other keywords are returned as pdfKeyword, the
+// end of a dictionary or array outside of one as well.
+func (l *pdfLexer) value() interface{} {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil
+	}
+	switch c := l.data[l.pos]; {
+	case c == '/':
+		l.pos++
+		return pdfName(decodePDFName(l.token()))
+	case c == '(':
+		l.pos++
+		return l.literalString()
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.dict()
+	case c == '<':
+		l.pos++
+		return l.hexString()
+	case c == '[':
+		l.pos++
+		return l.array()
+	case c == ']' || c == '>' || c == ')' || c == '{' || c == '}':
+		l.pos++
+		if c == '>' && l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+			return pdfKeyword(">>")
+		}
+		return pdfKeyword([]byte{c})
+	}
+
+	token := l.token()
+	if token == "" {
+		// A delimiter the switch does not handle, skip it.
+		l.pos++
+		return pdfKeyword("")
+	}
+	switch token {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	n, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return pdfKeyword(token)
+	}
+	// "num gen R" is a reference.
+	if num := int(n); float64(num) == n && num >= 0 {
+		save := l.pos
+		l.skipSpace()
+		gen := l.token()
+		l.skipSpace()
+		if g, err := strconv.Atoi(gen); err == nil && l.pos < len(l.data) && l.data[l.pos] == 'R' &&
+			(l.pos+1 == len(l.data) || isPDFSpace(l.data[l.pos+1]) || isPDFDelimiter(l.data[l.pos+1])) {
+			l.pos++
+			return pdfRef{num: num, gen: g}
+		}
+		l.pos = save
+	}
+	return n
+}
+
+func (l *pdfLexer) dict() pdfDict {
+	dict := pdfDict{}
+	if !l.enter() {
+		return dict
+	}
+	defer l.leave()
+	for !l.eof() {
+		key := l.value()
+		if key == pdfKeyword(">>") {
+			break
+		}
+		name, ok := key.(pdfName)
+		if !ok {
+			continue
+		}
+		dict[name] = l.value()
+	}
+	return dict
+}
+
+func (l *pdfLexer) array() pdfArray {
+	array := pdfArray{}
+	if !l.enter() {
+		return array
+	}
+	defer l.leave()
+	for !l.eof() {
+		value := l.value()
+		if value == pdfKeyword("]") {
+			break
+		}
+		array = append(array, value)
+	}
+	return array
+}
+
+// literalString reads a string in parentheses, after the opening one.
+func (l *pdfLexer) literalString() pdfString {
+	var s []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return s
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// A line continuation.
+				if c == '\r' && l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				n := int(c - '0')
+				for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+					n = n*8 + int(l.data[l.pos]-'0')
+					l.pos++
+				}
+				c = byte(n)
+			}
+		}
+		s = append(s, c)
+	}
+	return s
+}
+
+// hexString reads a string of hexadecimal digits, after the opening "<".
+func (l *pdfLexer) hexString() pdfString {
+	var s []byte
+	digits := 0
+	var current byte
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		if c == '>' {
+			break
+		}
+		digit, ok := hexDigit(c)
+		if !ok {
+			continue
+		}
+		current = current<<4 | digit
+		if digits++; digits%2 == 0 {
+			s = append(s, current)
+			current = 0
+		}
+	}
+	// A missing last digit is 0.
+	if digits%2 == 1 {
+		s = append(s, current<<4)
+	}
+	return s
+}
+
+// skipInlineImage skips the data of an inline image, after its ID operator.
+func (l *pdfLexer) skipInlineImage() {
+	for i := l.pos; i+2 < len(l.data); i++ {
+		if isPDFSpace(l.data[i]) && l.data[i+1] == 'E' && l.data[i+2] == 'I' &&
+			(i+3 == len(l.data) || isPDFSpace(l.data[i+3])) {
+			l.pos = i + 3
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
+
+// decodePDFName decodes the #xx escapes of a name.
+func decodePDFName(name string) string {
+	if !strings.ContainsRune(name, '#') {
+		return name
+	}
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		if name[i] == '#' && i+2 < len(name) {
+			high, ok1 := hexDigit(name[i+1])
+			low, ok2 := hexDigit(name[i+2])
+			if ok1 && ok2 {
+				b = append(b, high<<4|low)
+				i += 2
+				continue
+			}
+		}
+		b = append(b, name[i])
+	}
+	return string(b)
+}
+
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,99 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// The image formats whose dimensions can be decoded.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// imageProbeByteLimit is the number of bytes read from a probed image, enough for the headers
+	// of the usual formats.
+	imageProbeByteLimit = 64 << 10
+	// imageProbeTimeout bounds the probe of a single image.
+	imageProbeTimeout = 5 * time.Second
+	// maxProbedImages is the number of best ranked candidates which are probed.
+	maxProbedImages = 10
+)
+
+// ImageProbe is what probing an image found out about it.
+type ImageProbe struct {
+	ContentType string
+	// Width and Height are 0 if the format can not be decoded (e.g. SVG or WebP).
+	Width  int
+	Height int
+}
+
+// probeImages probes the candidates concurrently, with the fetch options of the page. The broken
+// and tiny ones are rejected, with the reason added to their reasons.
+func (e *Extractor) probeImages(ctx context.Context, candidates []ImageCandidate, options FetchOptions) {
+	var wg sync.WaitGroup
+	for i := range candidates {
+		wg.Add(1)
+		go func(candidate *ImageCandidate) {
+			defer wg.Done()
+			probe, err := e.probeImage(ctx, candidate.URL, options)
+			if err == nil && probe.Width != 0 && (probe.Width < minImageSide || probe.Height < minImageSide) {
+				err = fmt.Errorf("too small (%dx%d)", probe.Width, probe.Height)
+			}
+			candidate.Probe = probe
+			if err != nil {
+				candidate.Rejected = true
+				candidate.Reasons = append(candidate.Reasons, "rejected by probe: "+err.Error())
+			}
+		}(&candidates[i])
+	}
+	wg.Wait()
+}
+
+// probeImage fetches the first bytes of the image at imageUrl and decodes its type and
+// dimensions. It returns an error if the image can not be fetched or is not an image. The
+// headers, timeouts and body size limit of options apply, like for the page.
+func (e *Extractor) probeImage(ctx context.Context, imageUrl string, options FetchOptions) (*ImageProbe, error) {
+	config := e.fetcher.Config()
+	timeout := capDuration(imageProbeTimeout, capDuration(options.Timeout, config.TotalTimeout))
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if connectTimeout := capDuration(options.ConnectTimeout, config.ConnectTimeout); connectTimeout > 0 {
+		ctx = context.WithValue(ctx, connectTimeoutKey{}, connectTimeout)
+	}
+	byteLimit := capInt64(imageProbeByteLimit, capInt64(options.MaxBodyBytes, config.MaxBodyBytes))
+
+	request, err := e.fetcher.newRequest(ctx, imageUrl, options)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Range", "bytes=0-"+strconv.FormatInt(byteLimit-1, 10))
+	request.Header.Set("Accept", "image/*")
+	response, err := e.fetcher.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		return nil, fmt.Errorf("status %d", response.StatusCode)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	imageConfig, format, err := image.DecodeConfig(io.LimitReader(response.Body, byteLimit))
+	if err != nil {
+		// Formats without a standard decoder are trusted if served as images.
+		if strings.HasPrefix(contentType, "image/") {
+			return &ImageProbe{ContentType: contentType}, nil
+		}
+		return nil, fmt.Errorf("not an image (%s)", firstNonEmpty(contentType, "unknown type"))
+	}
+	return &ImageProbe{ContentType: "image/" + format, Width: imageConfig.Width, Height: imageConfig.Height}, nil
+}
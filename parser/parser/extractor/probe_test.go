@@ -0,0 +1,106 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestImageProbing(t *testing.T) {
+	encodePNG := func(width, height int) []byte {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	photo, pixel := encodePNG(640, 480), encodePNG(1, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><article>
+			<img src="/missing.png" alt="A missing image with a long alt text">
+			<img src="/pixel.png" alt="A tracking pixel with a long alt text">
+			<img src="/page.png" alt="Not an image">
+			<img src="/photo.png" alt="Photo">
+		</article></body></html>`))
+	})
+	mux.HandleFunc("/pixel.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pixel)
+	})
+	mux.HandleFunc("/photo.png", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") == "" {
+			t.Error("Expected a Range header")
+		}
+		w.Write(photo)
+	})
+	mux.HandleFunc("/page.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	e := New()
+	result, err := e.ExtractFromURL(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ThumbnailURL != server.URL+"/missing.png" || result.ThumbnailProbe != nil {
+		t.Errorf("Expected the unprobed best ranked image, got %s %v", result.ThumbnailURL, result.ThumbnailProbe)
+	}
+
+	result, err = e.ExtractFromURL(context.Background(), server.URL+"/article", WithImageProbing())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ThumbnailURL != server.URL+"/photo.png" {
+		t.Errorf("Expected the photo as thumbnail, got %s", result.ThumbnailURL)
+	}
+	want := ImageProbe{ContentType: "image/png", Width: 640, Height: 480}
+	if result.ThumbnailProbe == nil || *result.ThumbnailProbe != want {
+		t.Errorf("Expected %v, got %v", want, result.ThumbnailProbe)
+	}
+	for _, image := range result.Images {
+		if image.Rejected != (image.URL != server.URL+"/photo.png") {
+			t.Errorf("Unexpected rejection of %s: %v", image.URL, image.Reasons)
+		}
+		if image.Rejected && !strings.HasPrefix(image.Reasons[len(image.Reasons)-1], "rejected by probe: ") {
+			t.Errorf("Expected a probe reason for %s, got %v", image.URL, image.Reasons)
+		}
+	}
+}
+
+func TestImageProbingFetchOptions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 640, 480))); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><img src="/photo.png" alt="Photo"></body></html>`))
+	})
+	mux.HandleFunc("/photo.png", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "TestBot/1.0" || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write(buf.Bytes())
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := FetchOptions{UserAgent: "TestBot/1.0", Headers: map[string]string{"X-Token": "secret"}}
+	result, err := New().ExtractFromURL(context.Background(), server.URL+"/article", WithImageProbing(), WithFetchOptions(options))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ThumbnailProbe == nil || result.ThumbnailProbe.Width != 640 {
+		t.Errorf("Expected the probe to send the page headers, got %v", result.Images)
+	}
+}
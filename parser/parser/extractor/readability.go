@@ -0,0 +1,165 @@
+package extractor
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Class and id hints used to score the content candidates, as in Arc90's Readability.
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)banner|breadcrumbs|combx|comment|community|cookie|cover-wrap|disqus|extra|footer|gdpr|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|yom-remote|newsletter|subscribe|share`)
+	maybeCandidate     = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow|story|entry|post`)
+	positiveHints      = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
+	negativeHints      = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget|cookie|newsletter|subscribe`)
+)
+
+const (
+	// The block elements which are not content when they are mostly links.
+	contentBlocks = "p, pre, li, h2, h3, h4, h5, h6, blockquote, td"
+	// Paragraphs shorter than this are not scored.
+	minParagraphLength = 25
+	// Ratio of the top score a sibling needs to be kept with the top candidate.
+	siblingScoreRatio = 0.2
+)
+
+// getReadableContent detects the main content of the page by scoring its blocks (text length,
+// commas, link density, class/id hints) and returns its text and nodes, boilerplate (navigation,
+// footers, cookie banners, comments, ...) removed. It returns an empty string if nothing looks like content.
+func getReadableContent(document *goquery.Document) (string, []*html.Node) {
+	// Work on a copy, the document is still used by the other extractors.
+	document = goquery.CloneDocument(document)
+	document.Find("script, style, noscript, iframe, form, nav, aside, footer, header, svg, button, select, textarea").Remove()
+
+	// Remove the elements which are unlikely to be content.
+	document.Find("body *").Each(func(index int, item *goquery.Selection) {
+		if item.Is("body, article, main") {
+			return
+		}
+		hints := item.AttrOr("class", "") + " " + item.AttrOr("id", "")
+		if unlikelyCandidates.MatchString(hints) && !maybeCandidate.MatchString(hints) {
+			item.Remove()
+		}
+	})
+
+	// Score the parents and grand parents of every paragraph.
+	scores := make(map[*html.Node]float64)
+	var candidates []*goquery.Selection
+	addCandidate := func(item *goquery.Selection) {
+		node := item.Get(0)
+		if _, ok := scores[node]; !ok {
+			scores[node] = initialScore(item)
+			candidates = append(candidates, item)
+		}
+	}
+	document.Find("p, pre, td").Each(func(index int, item *goquery.Selection) {
+		text := normalizeSpace(item.Text())
+		if len(text) < minParagraphLength {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+
+		parent := item.Parent()
+		if parent.Length() == 0 || parent.Is("html") {
+			return
+		}
+		addCandidate(parent)
+		scores[parent.Get(0)] += score
+
+		grandParent := parent.Parent()
+		if grandParent.Length() != 0 && !grandParent.Is("html") {
+			addCandidate(grandParent)
+			scores[grandParent.Get(0)] += score / 2
+		}
+	})
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	// Scale the scores by the link density, link lists are not content.
+	for _, candidate := range candidates {
+		scores[candidate.Get(0)] *= 1 - linkDensity(candidate)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].Get(0)] > scores[candidates[j].Get(0)]
+	})
+	top := candidates[0]
+	topScore := scores[top.Get(0)]
+
+	// Keep the siblings of the top candidate that look like content too (articles split into several divs).
+	threshold := math.Max(10, topScore*siblingScoreRatio)
+	var nodes []*html.Node
+	top.Parent().Children().Each(func(index int, sibling *goquery.Selection) {
+		keep := sibling.Get(0) == top.Get(0)
+		if score, ok := scores[sibling.Get(0)]; ok && score >= threshold {
+			keep = true
+		}
+		if !keep && sibling.Is("p") {
+			text := normalizeSpace(sibling.Text())
+			density := linkDensity(sibling)
+			keep = (len(text) > 80 && density < 0.25) || (len(text) > 0 && density == 0 && strings.HasSuffix(text, "."))
+		}
+		if keep {
+			// The blocks which are mostly links are not content either.
+			sibling.Find(contentBlocks).FilterFunction(func(index int, block *goquery.Selection) bool {
+				return linkDensity(block) > 0.5
+			}).Remove()
+			nodes = append(nodes, sibling.Nodes...)
+		}
+	})
+	// The flat content is the text of the blocks, so both are in the same order.
+	content := blocksText(getNodesBlocks(nodes))
+	if content == "" {
+		return "", nil
+	}
+	return content, nodes
+}
+
+// initialScore scores a candidate by its tag and its class/id hints.
+func initialScore(item *goquery.Selection) float64 {
+	score := 0.0
+	switch goquery.NodeName(item) {
+	case "div", "article", "main", "section":
+		score += 5
+	case "pre", "td", "blockquote":
+		score += 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score -= 3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score -= 5
+	}
+	for _, hint := range []string{item.AttrOr("class", ""), item.AttrOr("id", "")} {
+		if hint == "" {
+			continue
+		}
+		if negativeHints.MatchString(hint) {
+			score -= 25
+		}
+		if positiveHints.MatchString(hint) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity returns the ratio of the text of item which is inside links.
+func linkDensity(item *goquery.Selection) float64 {
+	length := len(normalizeSpace(item.Text()))
+	if length == 0 {
+		return 0
+	}
+	linkLength := 0
+	item.Find("a").Each(func(index int, link *goquery.Selection) {
+		linkLength += len(normalizeSpace(link.Text()))
+	})
+	return float64(linkLength) / float64(length)
+}
+
+// normalizeSpace trims text and collapses its whitespace.
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
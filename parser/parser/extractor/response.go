@@ -0,0 +1,147 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// statusError returns the error matching the status of a response, nil for success statuses.
+func statusError(response *FetchResponse) error {
+	code := response.StatusCode
+	if code >= 200 && code < 300 {
+		return nil
+	}
+
+	err := fmt.Errorf("HTTP status %d %s", code, http.StatusText(code))
+	kind := KindBadStatus
+	switch {
+	case code == http.StatusNotFound || code == http.StatusGone:
+		kind = KindNotFound
+	case code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusUnavailableForLegalReasons:
+		kind = KindPermissionDenied
+	case code == http.StatusRequestTimeout || code == http.StatusGatewayTimeout:
+		kind = KindTimeout
+	case code == http.StatusTooManyRequests || code >= 500:
+		kind = KindUnavailable
+	}
+	return &Error{Kind: kind, URL: response.URL, Err: err}
+}
+
+// getMediaType returns the media type of a response from its Content-Type header, or sniffed
+// from its body if the header is missing or invalid.
+func getMediaType(response *FetchResponse) string {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil || mediaType == "" || mediaType == "application/octet-stream" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(response.Body))
+	}
+	return strings.ToLower(mediaType)
+}
+
+// extractResponse extracts a fetched page according to its media type.
+func (e *Extractor) extractResponse(ctx context.Context, response *FetchResponse, opts *extractOptions) (*Result, error) {
+	mediaType := getMediaType(response)
+	opts.report(ProgressFetched, &Result{URL: response.URL, ContentType: mediaType})
+
+	var result *Result
+	var err error
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		// Follow redirects, the final URL is the base for relative links.
+		opts.contentType = response.Header.Get("Content-Type")
+		result, err = e.extractReader(ctx, bytes.NewReader(response.Body), response.URL, opts)
+	case mediaType == "text/plain":
+		result = extractText(response, opts)
+	case strings.HasPrefix(mediaType, "image/"):
+		result = extractImage(response, mediaType)
+	case mediaType == "application/pdf":
+		result, err = e.extractPDF(ctx, response, opts)
+	default:
+		err = &Error{Kind: KindUnsupportedType, URL: response.URL, Err: fmt.Errorf("unsupported content type %q", mediaType)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.ContentType = mediaType
+	return result, nil
+}
+
+// extractText returns a plain text page as the content, one paragraph per line.
+func extractText(response *FetchResponse, opts *extractOptions) *Result {
+	content, textCharset := decodeCharset(response.Body, response.Header.Get("Content-Type"))
+	nodes := getTextNodes(string(content))
+	result := &Result{
+		URL:       response.URL,
+		Charset:   textCharset,
+		Content:   normalizeSpace(string(content)),
+		Extractor: TextExtractorName,
+	}
+	opts.renderContent(result, nodes, response.URL)
+	result.WordCount = countWords(result.Content)
+	return result
+}
+
+// extractPDF returns the title, author, dates and keywords of the info dictionary of a PDF
+// document, its page count and its text as the content, one paragraph per line. Its decompressed
+// streams are bounded by the body size limit of the fetch.
+func (e *Extractor) extractPDF(ctx context.Context, response *FetchResponse, opts *extractOptions) (*Result, error) {
+	maxBytes := capInt64(opts.fetch.MaxBodyBytes, e.fetcher.Config().MaxBodyBytes)
+	info, err := readPDF(ctx, response.Body, maxBytes)
+	switch {
+	case err == nil:
+	case err == errPDFTooLarge:
+		return nil, &Error{Kind: KindTooLarge, URL: response.URL, Err: err}
+	case ctx.Err() != nil:
+		return nil, contextError(ctx, response.URL)
+	default:
+		return nil, &Error{Kind: KindUnparsable, URL: response.URL, Err: err}
+	}
+	text := strings.Join(info.Pages, "\n")
+	result := &Result{
+		URL:           response.URL,
+		Title:         info.Title,
+		Content:       normalizeSpace(text),
+		Description:   info.Subject,
+		PublishedTime: info.Created,
+		ModifiedTime:  info.Modified,
+		PageCount:     info.PageCount,
+		Extractor:     PDFExtractorName,
+	}
+	if info.Author != "" {
+		result.Authors = []string{info.Author}
+	}
+	for _, keyword := range strings.FieldsFunc(info.Keywords, func(r rune) bool { return r == ',' || r == ';' }) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			result.Keywords = append(result.Keywords, keyword)
+		}
+	}
+	opts.renderContent(result, getTextNodes(text), response.URL)
+	result.WordCount = countWords(result.Content)
+	return result, nil
+}
+
+// extractImage returns an image as the thumbnail of the page itself. Its dimensions are
+// decoded if the format is supported.
+func extractImage(response *FetchResponse, mediaType string) *Result {
+	probe := &ImageProbe{ContentType: mediaType}
+	if config, format, err := image.DecodeConfig(bytes.NewReader(response.Body)); err == nil {
+		probe = &ImageProbe{ContentType: "image/" + format, Width: config.Width, Height: config.Height}
+	}
+	return &Result{
+		URL:            response.URL,
+		ThumbnailURL:   response.URL,
+		ThumbnailProbe: probe,
+		Images: []ImageCandidate{{
+			URL:     response.URL,
+			Reasons: []string{"the page is an image"},
+			Width:   probe.Width,
+			Height:  probe.Height,
+			Probe:   probe,
+		}},
+		Extractor: ImageExtractorName,
+	}
+}
@@ -0,0 +1,58 @@
+package extractor
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		code int
+		want ErrorKind
+	}{
+		{code: http.StatusOK, want: KindUnknown},
+		{code: http.StatusNoContent, want: KindUnknown},
+		{code: http.StatusNotFound, want: KindNotFound},
+		{code: http.StatusGone, want: KindNotFound},
+		{code: http.StatusForbidden, want: KindPermissionDenied},
+		{code: http.StatusTooManyRequests, want: KindUnavailable},
+		{code: http.StatusBadGateway, want: KindUnavailable},
+		{code: http.StatusGatewayTimeout, want: KindTimeout},
+		{code: http.StatusBadRequest, want: KindBadStatus},
+		{code: http.StatusNotModified, want: KindBadStatus},
+	}
+	for _, tt := range tests {
+		err := statusError(&FetchResponse{URL: "https://example.com", StatusCode: tt.code})
+		if KindOf(err) != tt.want {
+			t.Errorf("%d: expected %s, got %v", tt.code, tt.want, err)
+		}
+	}
+}
+
+func TestGetMediaType(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		contentType string
+		body        []byte
+		want        string
+	}{
+		{contentType: "text/HTML; charset=utf-8", want: "text/html"},
+		{contentType: "application/xhtml+xml", want: "application/xhtml+xml"},
+		// Missing, invalid or generic types are sniffed.
+		{contentType: "", body: []byte("<!DOCTYPE html><html></html>"), want: "text/html"},
+		{contentType: "application/octet-stream", body: buf.Bytes(), want: "image/png"},
+		{contentType: "invalid;;", body: []byte("Plain text"), want: "text/plain"},
+	}
+	for _, tt := range tests {
+		response := &FetchResponse{Header: http.Header{"Content-Type": {tt.contentType}}, Body: tt.body}
+		if got := getMediaType(response); got != tt.want {
+			t.Errorf("%q: expected %s, got %s", tt.contentType, tt.want, got)
+		}
+	}
+}
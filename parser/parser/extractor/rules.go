@@ -0,0 +1,148 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v2"
+)
+
+// Rule is a declarative, per-domain extraction rule. Rules are loaded from a JSON or YAML
+// file (see LoadRules) and turned into SelectorExtractors.
+type Rule struct {
+	// Name identifies the rule in the results. A rule named like a built-in site extractor
+	// ("medium", "bbc", "foxnews") replaces it.
+	Name  string   `json:"name" yaml:"name"`
+	Hosts []string `json:"hosts" yaml:"hosts"`
+	// Detect matches the pages of any host containing the selector.
+	Detect string `json:"detect" yaml:"detect"`
+
+	Title         string `json:"title" yaml:"title"`
+	Image         string `json:"image" yaml:"image"`
+	Content       string `json:"content" yaml:"content"`
+	ContentFilter string `json:"content_filter" yaml:"content_filter"`
+	// Strip lists the elements removed before the extraction.
+	Strip []string `json:"strip" yaml:"strip"`
+	// DropText lists the texts whose content children are dropped.
+	DropText []string `json:"drop_text" yaml:"drop_text"`
+}
+
+// rulesFile is the layout of a rules file.
+type rulesFile struct {
+	Rules []*Rule `json:"rules" yaml:"rules"`
+}
+
+// Validate checks that the rule can match pages, extracts something and has valid selectors.
+func (rule *Rule) Validate() error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return fmt.Errorf("missing name")
+	}
+	if len(rule.Hosts) == 0 && rule.Detect == "" {
+		return fmt.Errorf("rule %q: one of hosts or detect is required", rule.Name)
+	}
+	for _, host := range rule.Hosts {
+		if strings.TrimSpace(host) == "" || strings.ContainsAny(host, "/: ") {
+			return fmt.Errorf("rule %q: invalid host %q", rule.Name, host)
+		}
+	}
+	if rule.Title == "" && rule.Image == "" && rule.Content == "" {
+		return fmt.Errorf("rule %q: one of title, image or content is required", rule.Name)
+	}
+	if rule.ContentFilter != "" && rule.Content == "" {
+		return fmt.Errorf("rule %q: content_filter requires content", rule.Name)
+	}
+
+	selectors := map[string]string{
+		"detect":         rule.Detect,
+		"title":          rule.Title,
+		"image":          rule.Image,
+		"content":        rule.Content,
+		"content_filter": rule.ContentFilter,
+	}
+	for i, strip := range rule.Strip {
+		selectors[fmt.Sprintf("strip[%d]", i)] = strip
+	}
+	for field, selector := range selectors {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.Compile(selector); err != nil {
+			return fmt.Errorf("rule %q: invalid %s selector: %v", rule.Name, field, err)
+		}
+	}
+	return nil
+}
+
+// SiteExtractor returns the SiteExtractor applying the rule.
+func (rule *Rule) SiteExtractor() SiteExtractor {
+	return &SelectorExtractor{
+		SiteName:        rule.Name,
+		Hosts:           rule.Hosts,
+		DetectSelector:  rule.Detect,
+		TitleSelector:   rule.Title,
+		ImageSelector:   rule.Image,
+		ContentSelector: rule.Content,
+		ContentFilter:   rule.ContentFilter,
+		StripSelector:   strings.Join(rule.Strip, ", "),
+		DropText:        rule.DropText,
+	}
+}
+
+// LoadRules reads and validates the rules file at path. Files ending with ".yaml" or ".yml"
+// are decoded as YAML, others as JSON.
+func LoadRules(path string) ([]*Rule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rulesFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, &file)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	names := make(map[string]bool)
+	for i, rule := range file.Rules {
+		if rule == nil {
+			return nil, fmt.Errorf("%s: rule %d is empty", path, i)
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: rule %d: %v", path, i, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("%s: rule %d: duplicate name %q", path, i, rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	return file.Rules, nil
+}
+
+// SitesWithRules returns the extractors of rules, in file order, followed by the built-in
+// site extractors that are not replaced by a rule of the same name.
+func SitesWithRules(rules []*Rule) []SiteExtractor {
+	var sites []SiteExtractor
+	names := make(map[string]bool)
+	for _, rule := range rules {
+		sites = append(sites, rule.SiteExtractor())
+		names[rule.Name] = true
+	}
+	for _, site := range BuiltinSites() {
+		if !names[site.Name()] {
+			sites = append(sites, site)
+		}
+	}
+	return sites
+}
@@ -0,0 +1,58 @@
+package extractor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The example rules file must describe exactly the built-in extractors.
+func TestLoadRulesExample(t *testing.T) {
+	rules, err := LoadRules("../rules.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sites := SitesWithRules(rules)
+	if !reflect.DeepEqual(sites, BuiltinSites()) {
+		t.Errorf("Expected the built-in extractors, got %+v", sites)
+	}
+}
+
+func TestLoadRulesInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{name: "valid", rules: `{"rules": [{"name": "example", "hosts": ["example.com"], "content": ".post"}]}`},
+		{name: "unknown field", rules: `{"rules": [{"name": "example", "hosts": ["example.com"], "contnet": ".post"}]}`, wantErr: "unknown field"},
+		{name: "no name", rules: `{"rules": [{"hosts": ["example.com"], "content": ".post"}]}`, wantErr: "missing name"},
+		{name: "no host", rules: `{"rules": [{"name": "example", "content": ".post"}]}`, wantErr: "hosts or detect"},
+		{name: "bad host", rules: `{"rules": [{"name": "example", "hosts": ["https://example.com"], "content": ".post"}]}`, wantErr: "invalid host"},
+		{name: "no selector", rules: `{"rules": [{"name": "example", "hosts": ["example.com"]}]}`, wantErr: "title, image or content"},
+		{name: "bad selector", rules: `{"rules": [{"name": "example", "hosts": ["example.com"], "content": "div[["}]}`, wantErr: "invalid content selector"},
+		{name: "duplicate", rules: `{"rules": [{"name": "a", "hosts": ["a.com"], "content": "p"}, {"name": "a", "hosts": ["b.com"], "content": "p"}]}`, wantErr: "duplicate name"},
+	}
+	for i, tt := range tests {
+		path := filepath.Join(dir, strings.Replace(tt.name, " ", "_", -1)+".json")
+		if err := ioutil.WriteFile(path, []byte(tt.rules), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadRules(path)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%d %s: unexpected error %v", i, tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%d %s: expected error containing %q, got %v", i, tt.name, tt.wantErr, err)
+		}
+	}
+}
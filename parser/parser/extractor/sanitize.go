@@ -0,0 +1,103 @@
+package extractor
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// The elements kept by renderSanitizedHTML, with their allowed attributes. The other elements
+// are unwrapped (their children are kept), except skippedElements which are dropped.
+var allowedElements = map[string][]string{
+	"p": nil, "br": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"blockquote": nil, "pre": nil, "code": nil,
+	"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+	"figure": nil, "figcaption": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "ins": nil,
+	"sub": nil, "sup": nil, "mark": nil, "small": nil, "abbr": {"title"}, "cite": nil, "q": nil,
+	"kbd": nil, "samp": nil, "var": nil, "time": {"datetime"},
+	"table": nil, "caption": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+	"th": {"colspan", "rowspan", "scope"}, "td": {"colspan", "rowspan"},
+	"dl": nil, "dt": nil, "dd": nil,
+}
+
+// renderSanitizedHTML renders the content nodes as HTML keeping only the allowlisted elements
+// and attributes. Links and images are resolved against baseURL, the ones which are not
+// http(s) are dropped. Scripts, styles and event handlers never make it to the output.
+func renderSanitizedHTML(nodes []*html.Node, baseURL string) string {
+	root := &html.Node{Type: html.DocumentNode}
+	for _, node := range nodes {
+		sanitizeNode(root, node, baseURL)
+	}
+
+	var buf bytes.Buffer
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(&buf, child); err != nil {
+			return ""
+		}
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// sanitizeNode appends the sanitized copy of node to parent.
+func sanitizeNode(parent, node *html.Node, baseURL string) {
+	switch node.Type {
+	case html.TextNode:
+		parent.AppendChild(&html.Node{Type: html.TextNode, Data: node.Data})
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		sanitizeChildren(parent, node, baseURL)
+		return
+	default:
+		return
+	}
+
+	if skippedElements[node.Data] {
+		return
+	}
+	allowedAttrs, ok := allowedElements[node.Data]
+	if !ok {
+		sanitizeChildren(parent, node, baseURL)
+		return
+	}
+
+	clean := &html.Node{Type: html.ElementNode, Data: node.Data, DataAtom: node.DataAtom}
+	for _, key := range allowedAttrs {
+		value := getAttr(node, key)
+		switch key {
+		case "href":
+			value = safeURL(baseURL, value, true)
+		case "src":
+			// Lazy-loaded images keep their source in data-src or srcset.
+			value = safeURL(baseURL, nodeImageSource(node), false)
+		}
+		if value != "" {
+			clean.Attr = append(clean.Attr, html.Attribute{Key: key, Val: value})
+		}
+	}
+	// Images without a safe source are useless, links without one are plain text.
+	if node.Data == "img" && getAttr(clean, "src") == "" {
+		return
+	}
+	if node.Data == "a" && getAttr(clean, "href") == "" {
+		sanitizeChildren(parent, node, baseURL)
+		return
+	}
+	if node.Data == "a" {
+		clean.Attr = append(clean.Attr, html.Attribute{Key: "rel", Val: "nofollow noopener"})
+	}
+
+	sanitizeChildren(clean, node, baseURL)
+	parent.AppendChild(clean)
+}
+
+// sanitizeChildren appends the sanitized copies of the children of node to parent.
+func sanitizeChildren(parent, node *html.Node, baseURL string) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		sanitizeNode(parent, child, baseURL)
+	}
+}
@@ -0,0 +1,226 @@
+package extractor
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// GenericExtractorName is reported in Result.Extractor when no SiteExtractor handled the page.
+	GenericExtractorName = "generic"
+	// ReadabilityExtractorName is reported in Result.Extractor when the content was detected by ContentModeReadability.
+	ReadabilityExtractorName = "readability"
+	// TextExtractorName, ImageExtractorName and PDFExtractorName are reported in Result.Extractor
+	// for plain text, image and PDF pages.
+	TextExtractorName  = "text"
+	ImageExtractorName = "image"
+	PDFExtractorName   = "pdf"
+)
+
+// SiteResult holds the values a SiteExtractor found in a page. Empty values are left to
+// the generic extraction (Open Graph, JSON-LD and HTML heuristics).
+type SiteResult struct {
+	Title        string
+	ThumbnailURL string
+	Content      string
+	// Nodes are the HTML nodes Content was taken from, in document order. They are used for the
+	// content blocks and formats; if nil, those are made from the plain Content.
+	Nodes []*html.Node
+}
+
+func (sr SiteResult) empty() bool {
+	return sr.Title == "" && sr.ThumbnailURL == "" && sr.Content == ""
+}
+
+// SiteExtractor extracts the pages of a specific site (publisher).
+type SiteExtractor interface {
+	// Name identifies the extractor in the results, e.g. "medium".
+	Name() string
+	// Match reports whether the extractor handles the page. pageUrl is nil if the page URL is unknown.
+	Match(pageUrl *url.URL, document *goquery.Document) bool
+	// Extract returns the values found in the page.
+	Extract(document *goquery.Document) SiteResult
+}
+
+// Registry holds the SiteExtractors in the order they are tried. It is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	extractors []SiteExtractor
+}
+
+// NewRegistry returns a Registry trying the given extractors in order.
+func NewRegistry(extractors ...SiteExtractor) *Registry {
+	return &Registry{extractors: extractors}
+}
+
+// DefaultRegistry returns a Registry with the built-in extractors (Medium, BBC News and Fox News).
+func DefaultRegistry() *Registry {
+	return NewRegistry(BuiltinSites()...)
+}
+
+// Register adds extractors after the already registered ones.
+func (r *Registry) Register(extractors ...SiteExtractor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extractors = append(r.extractors, extractors...)
+}
+
+// Replace replaces all the registered extractors, e.g. when the rules file is reloaded.
+func (r *Registry) Replace(extractors ...SiteExtractor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extractors = extractors
+}
+
+// Extractors returns the registered extractors, in order.
+func (r *Registry) Extractors() []SiteExtractor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]SiteExtractor(nil), r.extractors...)
+}
+
+// Extract runs the first matching extractor that finds something in the page and returns its
+// name and result. The name is empty if no extractor handled the page.
+func (r *Registry) Extract(pageUrl *url.URL, document *goquery.Document) (string, SiteResult) {
+	if r == nil {
+		return "", SiteResult{}
+	}
+	for _, extractor := range r.Extractors() {
+		if !extractor.Match(pageUrl, document) {
+			continue
+		}
+		if result := extractor.Extract(document); !result.empty() {
+			return extractor.Name(), result
+		}
+	}
+	return "", SiteResult{}
+}
+
+// SelectorExtractor is a SiteExtractor driven by CSS selectors.
+type SelectorExtractor struct {
+	SiteName string
+	// Hosts the extractor is made for. Subdomains match too ("bbc.co.uk" matches "www.bbc.co.uk").
+	Hosts []string
+	// DetectSelector, if set, also matches pages of any host containing it (e.g. Medium
+	// publications on custom domains, or local files).
+	DetectSelector string
+
+	TitleSelector string
+	ImageSelector string
+	// ContentSelector selects the content containers. ContentFilter restricts their children
+	// to the given selector (e.g. "p"); all children, text included, are used if it is empty.
+	ContentSelector string
+	ContentFilter   string
+	// StripSelector removes the matching elements before the extraction (ads, share buttons, ...).
+	StripSelector string
+	// DropText drops the content children containing one of these strings.
+	DropText []string
+}
+
+func (se *SelectorExtractor) Name() string {
+	return se.SiteName
+}
+
+func (se *SelectorExtractor) Match(pageUrl *url.URL, document *goquery.Document) bool {
+	if pageUrl != nil && matchHost(pageUrl.Hostname(), se.Hosts) {
+		return true
+	}
+	return se.DetectSelector != "" && document.Find(se.DetectSelector).Length() != 0
+}
+
+func (se *SelectorExtractor) Extract(document *goquery.Document) SiteResult {
+	if se.StripSelector != "" {
+		// Work on a copy, the document is still used by the generic extraction.
+		document = goquery.CloneDocument(document)
+		document.Find(se.StripSelector).Remove()
+	}
+
+	var result SiteResult
+	if se.TitleSelector != "" {
+		result.Title = strings.TrimSpace(document.Find(se.TitleSelector).First().Text())
+	}
+	if se.ImageSelector != "" {
+		image := document.Find(se.ImageSelector).First()
+		result.ThumbnailURL = firstNonEmpty(imageSource(image), strings.TrimSpace(image.AttrOr("content", "")))
+	}
+	if se.ContentSelector == "" {
+		return result
+	}
+
+	var sb strings.Builder
+	var nodes []*html.Node
+	document.Find(se.ContentSelector).Each(func(index int, item *goquery.Selection) {
+		children := item.Contents()
+		if se.ContentFilter != "" {
+			children = item.ContentsFiltered(se.ContentFilter)
+		}
+		children.Each(func(i int, ctx *goquery.Selection) {
+			tmp := ctx.Text()
+			if tmp != "" && !containsAny(tmp, se.DropText) {
+				sb.WriteString(tmp)
+				sb.WriteString(" ")
+				nodes = append(nodes, ctx.Nodes...)
+			}
+		})
+	})
+	result.Content = strings.TrimSpace(sb.String())
+	result.Nodes = nodes
+	return result
+}
+
+// BuiltinSites returns the built-in site extractors.
+func BuiltinSites() []SiteExtractor {
+	return []SiteExtractor{
+		// Content parser for specific to Medium Blog.
+		&SelectorExtractor{
+			SiteName:        "medium",
+			Hosts:           []string{"medium.com"},
+			DetectSelector:  ".section-inner.sectionLayout--insetColumn",
+			ContentSelector: ".section-inner.sectionLayout--insetColumn",
+			DropText:        []string{"BlockedUnblockFollowFollowing"},
+		},
+		// Content parser for specific to BBC News.
+		&SelectorExtractor{
+			SiteName:        "bbc",
+			Hosts:           []string{"bbc.com", "bbc.co.uk"},
+			DetectSelector:  ".story-body__inner",
+			ContentSelector: ".story-body__inner",
+			ContentFilter:   "p",
+			DropText:        []string{"\n"},
+		},
+		// Content parser for specific to Fox News. Its ".article-body" class is used by many other
+		// sites, so only the host is matched.
+		&SelectorExtractor{
+			SiteName:        "foxnews",
+			Hosts:           []string{"foxnews.com"},
+			ContentSelector: ".article-body",
+			ContentFilter:   "p",
+			DropText:        []string{"\n"},
+		},
+	}
+}
+
+// matchHost reports whether host is one of hosts or one of their subdomains.
+func matchHost(host string, hosts []string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package extractor
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestRegistryMatchesHost(t *testing.T) {
+	const page = `<html><body><div class="post"><p>Post text</p></div><p>Footer</p></body></html>`
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := DefaultRegistry()
+	registry.Register(&SelectorExtractor{SiteName: "example", Hosts: []string{"example.com"}, ContentSelector: ".post", ContentFilter: "p"})
+
+	tests := []struct {
+		url         string
+		wantName    string
+		wantContent string
+	}{
+		{url: "https://www.example.com/post", wantName: "example", wantContent: "Post text"},
+		{url: "https://example.com/post", wantName: "example", wantContent: "Post text"},
+		{url: "https://notexample.com/post", wantName: "", wantContent: ""},
+	}
+	for _, tt := range tests {
+		pageUrl, _ := url.Parse(tt.url)
+		name, result := registry.Extract(pageUrl, document)
+		if name != tt.wantName || result.Content != tt.wantContent {
+			t.Errorf("%s: expected %q %q, got %q %q", tt.url, tt.wantName, tt.wantContent, name, result.Content)
+		}
+	}
+}
+
+// Pages of other hosts using the generic classes of the built-in sites are not theirs.
+func TestRegistryIgnoresGenericClasses(t *testing.T) {
+	const page = `<html><body><div class="article-body"><p>Other news</p></div></body></html>`
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pageUrl, _ := url.Parse("https://news.example.com/story")
+	if name, _ := DefaultRegistry().Extract(pageUrl, document); name != "" {
+		t.Errorf("expected no site extractor, got %q", name)
+	}
+	pageUrl, _ = url.Parse("https://www.foxnews.com/story")
+	if name, result := DefaultRegistry().Extract(pageUrl, document); name != "foxnews" || result.Content != "Other news" {
+		t.Errorf("expected the foxnews extractor, got %q %q", name, result.Content)
+	}
+}
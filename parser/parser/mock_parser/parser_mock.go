@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 	parserproto "parser/parser/parserproto"
 	reflect "reflect"
 )
@@ -35,6 +36,86 @@ func (m *MockParserServiceClient) EXPECT() *MockParserServiceClientMockRecorder
 	return m.recorder
 }
 
+// BatchParse mocks base method
+func (m *MockParserServiceClient) BatchParse(ctx context.Context, in *parserproto.BatchParserRequest, opts ...grpc.CallOption) (*parserproto.BatchParserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchParse", varargs...)
+	ret0, _ := ret[0].(*parserproto.BatchParserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchParse indicates an expected call of BatchParse
+func (mr *MockParserServiceClientMockRecorder) BatchParse(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchParse", reflect.TypeOf((*MockParserServiceClient)(nil).BatchParse), varargs...)
+}
+
+// CancelParseJob mocks base method
+func (m *MockParserServiceClient) CancelParseJob(ctx context.Context, in *parserproto.CancelParseJobRequest, opts ...grpc.CallOption) (*parserproto.ParseJob, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelParseJob", varargs...)
+	ret0, _ := ret[0].(*parserproto.ParseJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelParseJob indicates an expected call of CancelParseJob
+func (mr *MockParserServiceClientMockRecorder) CancelParseJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelParseJob", reflect.TypeOf((*MockParserServiceClient)(nil).CancelParseJob), varargs...)
+}
+
+// GetParseJob mocks base method
+func (m *MockParserServiceClient) GetParseJob(ctx context.Context, in *parserproto.GetParseJobRequest, opts ...grpc.CallOption) (*parserproto.ParseJob, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetParseJob", varargs...)
+	ret0, _ := ret[0].(*parserproto.ParseJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParseJob indicates an expected call of GetParseJob
+func (mr *MockParserServiceClientMockRecorder) GetParseJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParseJob", reflect.TypeOf((*MockParserServiceClient)(nil).GetParseJob), varargs...)
+}
+
+// ListParseJobs mocks base method
+func (m *MockParserServiceClient) ListParseJobs(ctx context.Context, in *parserproto.ListParseJobsRequest, opts ...grpc.CallOption) (*parserproto.ListParseJobsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListParseJobs", varargs...)
+	ret0, _ := ret[0].(*parserproto.ListParseJobsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParseJobs indicates an expected call of ListParseJobs
+func (mr *MockParserServiceClientMockRecorder) ListParseJobs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParseJobs", reflect.TypeOf((*MockParserServiceClient)(nil).ListParseJobs), varargs...)
+}
+
 // Parse mocks base method
 func (m *MockParserServiceClient) Parse(ctx context.Context, in *parserproto.ParserRequest, opts ...grpc.CallOption) (*parserproto.ParserResponse, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +136,66 @@ func (mr *MockParserServiceClientMockRecorder) Parse(ctx, in interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockParserServiceClient)(nil).Parse), varargs...)
 }
 
+// ParseFeed mocks base method
+func (m *MockParserServiceClient) ParseFeed(ctx context.Context, in *parserproto.FeedRequest, opts ...grpc.CallOption) (*parserproto.FeedResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ParseFeed", varargs...)
+	ret0, _ := ret[0].(*parserproto.FeedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseFeed indicates an expected call of ParseFeed
+func (mr *MockParserServiceClientMockRecorder) ParseFeed(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseFeed", reflect.TypeOf((*MockParserServiceClient)(nil).ParseFeed), varargs...)
+}
+
+// ParseProgressive mocks base method
+func (m *MockParserServiceClient) ParseProgressive(ctx context.Context, in *parserproto.ParserRequest, opts ...grpc.CallOption) (parserproto.ParserService_ParseProgressiveClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ParseProgressive", varargs...)
+	ret0, _ := ret[0].(parserproto.ParserService_ParseProgressiveClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseProgressive indicates an expected call of ParseProgressive
+func (mr *MockParserServiceClientMockRecorder) ParseProgressive(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseProgressive", reflect.TypeOf((*MockParserServiceClient)(nil).ParseProgressive), varargs...)
+}
+
+// ParseStream mocks base method
+func (m *MockParserServiceClient) ParseStream(ctx context.Context, opts ...grpc.CallOption) (parserproto.ParserService_ParseStreamClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ParseStream", varargs...)
+	ret0, _ := ret[0].(parserproto.ParserService_ParseStreamClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseStream indicates an expected call of ParseStream
+func (mr *MockParserServiceClientMockRecorder) ParseStream(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseStream", reflect.TypeOf((*MockParserServiceClient)(nil).ParseStream), varargs...)
+}
+
 // ParseTest mocks base method
 func (m *MockParserServiceClient) ParseTest(ctx context.Context, in *parserproto.ParserTestRequest, opts ...grpc.CallOption) (*parserproto.ParserResponse, error) {
 	m.ctrl.T.Helper()
@@ -75,6 +216,286 @@ func (mr *MockParserServiceClientMockRecorder) ParseTest(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseTest", reflect.TypeOf((*MockParserServiceClient)(nil).ParseTest), varargs...)
 }
 
+// MockParserService_ParseStreamClient is a mock of ParserService_ParseStreamClient interface
+type MockParserService_ParseStreamClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserService_ParseStreamClientMockRecorder
+}
+
+// MockParserService_ParseStreamClientMockRecorder is the mock recorder for MockParserService_ParseStreamClient
+type MockParserService_ParseStreamClientMockRecorder struct {
+	mock *MockParserService_ParseStreamClient
+}
+
+// NewMockParserService_ParseStreamClient creates a new mock instance
+func NewMockParserService_ParseStreamClient(ctrl *gomock.Controller) *MockParserService_ParseStreamClient {
+	mock := &MockParserService_ParseStreamClient{ctrl: ctrl}
+	mock.recorder = &MockParserService_ParseStreamClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockParserService_ParseStreamClient) EXPECT() *MockParserService_ParseStreamClientMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method
+func (m *MockParserService_ParseStreamClient) Send(arg0 *parserproto.ParseStreamRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockParserService_ParseStreamClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).Send), arg0)
+}
+
+// Recv mocks base method
+func (m *MockParserService_ParseStreamClient) Recv() (*parserproto.ParseStreamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*parserproto.ParseStreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockParserService_ParseStreamClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).Recv))
+}
+
+// Header mocks base method
+func (m *MockParserService_ParseStreamClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header
+func (mr *MockParserService_ParseStreamClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).Header))
+}
+
+// Trailer mocks base method
+func (m *MockParserService_ParseStreamClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer
+func (mr *MockParserService_ParseStreamClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).Trailer))
+}
+
+// CloseSend mocks base method
+func (m *MockParserService_ParseStreamClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend
+func (mr *MockParserService_ParseStreamClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).CloseSend))
+}
+
+// Context mocks base method
+func (m *MockParserService_ParseStreamClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockParserService_ParseStreamClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).Context))
+}
+
+// SendMsg mocks base method
+func (m_2 *MockParserService_ParseStreamClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockParserService_ParseStreamClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).SendMsg), m)
+}
+
+// RecvMsg mocks base method
+func (m_2 *MockParserService_ParseStreamClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockParserService_ParseStreamClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockParserService_ParseStreamClient)(nil).RecvMsg), m)
+}
+
+// MockParserService_ParseProgressiveClient is a mock of ParserService_ParseProgressiveClient interface
+type MockParserService_ParseProgressiveClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserService_ParseProgressiveClientMockRecorder
+}
+
+// MockParserService_ParseProgressiveClientMockRecorder is the mock recorder for MockParserService_ParseProgressiveClient
+type MockParserService_ParseProgressiveClientMockRecorder struct {
+	mock *MockParserService_ParseProgressiveClient
+}
+
+// NewMockParserService_ParseProgressiveClient creates a new mock instance
+func NewMockParserService_ParseProgressiveClient(ctrl *gomock.Controller) *MockParserService_ParseProgressiveClient {
+	mock := &MockParserService_ParseProgressiveClient{ctrl: ctrl}
+	mock.recorder = &MockParserService_ParseProgressiveClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockParserService_ParseProgressiveClient) EXPECT() *MockParserService_ParseProgressiveClientMockRecorder {
+	return m.recorder
+}
+
+// Recv mocks base method
+func (m *MockParserService_ParseProgressiveClient) Recv() (*parserproto.ParseProgressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*parserproto.ParseProgressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).Recv))
+}
+
+// Header mocks base method
+func (m *MockParserService_ParseProgressiveClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).Header))
+}
+
+// Trailer mocks base method
+func (m *MockParserService_ParseProgressiveClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).Trailer))
+}
+
+// CloseSend mocks base method
+func (m *MockParserService_ParseProgressiveClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).CloseSend))
+}
+
+// Context mocks base method
+func (m *MockParserService_ParseProgressiveClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).Context))
+}
+
+// SendMsg mocks base method
+func (m_2 *MockParserService_ParseProgressiveClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).SendMsg), m)
+}
+
+// RecvMsg mocks base method
+func (m_2 *MockParserService_ParseProgressiveClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockParserService_ParseProgressiveClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockParserService_ParseProgressiveClient)(nil).RecvMsg), m)
+}
+
+// SubmitParse mocks base method
+func (m *MockParserServiceClient) SubmitParse(ctx context.Context, in *parserproto.ParserRequest, opts ...grpc.CallOption) (*parserproto.ParseJob, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubmitParse", varargs...)
+	ret0, _ := ret[0].(*parserproto.ParseJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitParse indicates an expected call of SubmitParse
+func (mr *MockParserServiceClientMockRecorder) SubmitParse(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitParse", reflect.TypeOf((*MockParserServiceClient)(nil).SubmitParse), varargs...)
+}
+
 // MockParserServiceServer is a mock of ParserServiceServer interface
 type MockParserServiceServer struct {
 	ctrl     *gomock.Controller
@@ -98,6 +519,66 @@ func (m *MockParserServiceServer) EXPECT() *MockParserServiceServerMockRecorder
 	return m.recorder
 }
 
+// BatchParse mocks base method
+func (m *MockParserServiceServer) BatchParse(arg0 context.Context, arg1 *parserproto.BatchParserRequest) (*parserproto.BatchParserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchParse", arg0, arg1)
+	ret0, _ := ret[0].(*parserproto.BatchParserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchParse indicates an expected call of BatchParse
+func (mr *MockParserServiceServerMockRecorder) BatchParse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchParse", reflect.TypeOf((*MockParserServiceServer)(nil).BatchParse), arg0, arg1)
+}
+
+// CancelParseJob mocks base method
+func (m *MockParserServiceServer) CancelParseJob(arg0 context.Context, arg1 *parserproto.CancelParseJobRequest) (*parserproto.ParseJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelParseJob", arg0, arg1)
+	ret0, _ := ret[0].(*parserproto.ParseJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelParseJob indicates an expected call of CancelParseJob
+func (mr *MockParserServiceServerMockRecorder) CancelParseJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelParseJob", reflect.TypeOf((*MockParserServiceServer)(nil).CancelParseJob), arg0, arg1)
+}
+
+// GetParseJob mocks base method
+func (m *MockParserServiceServer) GetParseJob(arg0 context.Context, arg1 *parserproto.GetParseJobRequest) (*parserproto.ParseJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParseJob", arg0, arg1)
+	ret0, _ := ret[0].(*parserproto.ParseJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParseJob indicates an expected call of GetParseJob
+func (mr *MockParserServiceServerMockRecorder) GetParseJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParseJob", reflect.TypeOf((*MockParserServiceServer)(nil).GetParseJob), arg0, arg1)
+}
+
+// ListParseJobs mocks base method
+func (m *MockParserServiceServer) ListParseJobs(arg0 context.Context, arg1 *parserproto.ListParseJobsRequest) (*parserproto.ListParseJobsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListParseJobs", arg0, arg1)
+	ret0, _ := ret[0].(*parserproto.ListParseJobsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParseJobs indicates an expected call of ListParseJobs
+func (mr *MockParserServiceServerMockRecorder) ListParseJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParseJobs", reflect.TypeOf((*MockParserServiceServer)(nil).ListParseJobs), arg0, arg1)
+}
+
 // Parse mocks base method
 func (m *MockParserServiceServer) Parse(arg0 context.Context, arg1 *parserproto.ParserRequest) (*parserproto.ParserResponse, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +594,49 @@ func (mr *MockParserServiceServerMockRecorder) Parse(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockParserServiceServer)(nil).Parse), arg0, arg1)
 }
 
+// ParseFeed mocks base method
+func (m *MockParserServiceServer) ParseFeed(arg0 context.Context, arg1 *parserproto.FeedRequest) (*parserproto.FeedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseFeed", arg0, arg1)
+	ret0, _ := ret[0].(*parserproto.FeedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseFeed indicates an expected call of ParseFeed
+func (mr *MockParserServiceServerMockRecorder) ParseFeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseFeed", reflect.TypeOf((*MockParserServiceServer)(nil).ParseFeed), arg0, arg1)
+}
+
+// ParseProgressive mocks base method
+func (m *MockParserServiceServer) ParseProgressive(arg0 *parserproto.ParserRequest, arg1 parserproto.ParserService_ParseProgressiveServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseProgressive", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ParseProgressive indicates an expected call of ParseProgressive
+func (mr *MockParserServiceServerMockRecorder) ParseProgressive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseProgressive", reflect.TypeOf((*MockParserServiceServer)(nil).ParseProgressive), arg0, arg1)
+}
+
+// ParseStream mocks base method
+func (m *MockParserServiceServer) ParseStream(arg0 parserproto.ParserService_ParseStreamServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseStream", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ParseStream indicates an expected call of ParseStream
+func (mr *MockParserServiceServerMockRecorder) ParseStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseStream", reflect.TypeOf((*MockParserServiceServer)(nil).ParseStream), arg0)
+}
+
 // ParseTest mocks base method
 func (m *MockParserServiceServer) ParseTest(arg0 context.Context, arg1 *parserproto.ParserTestRequest) (*parserproto.ParserResponse, error) {
 	m.ctrl.T.Helper()
@@ -127,3 +651,271 @@ func (mr *MockParserServiceServerMockRecorder) ParseTest(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseTest", reflect.TypeOf((*MockParserServiceServer)(nil).ParseTest), arg0, arg1)
 }
+
+// MockParserService_ParseStreamServer is a mock of ParserService_ParseStreamServer interface
+type MockParserService_ParseStreamServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserService_ParseStreamServerMockRecorder
+}
+
+// MockParserService_ParseStreamServerMockRecorder is the mock recorder for MockParserService_ParseStreamServer
+type MockParserService_ParseStreamServerMockRecorder struct {
+	mock *MockParserService_ParseStreamServer
+}
+
+// NewMockParserService_ParseStreamServer creates a new mock instance
+func NewMockParserService_ParseStreamServer(ctrl *gomock.Controller) *MockParserService_ParseStreamServer {
+	mock := &MockParserService_ParseStreamServer{ctrl: ctrl}
+	mock.recorder = &MockParserService_ParseStreamServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockParserService_ParseStreamServer) EXPECT() *MockParserService_ParseStreamServerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method
+func (m *MockParserService_ParseStreamServer) Send(arg0 *parserproto.ParseStreamResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockParserService_ParseStreamServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).Send), arg0)
+}
+
+// Recv mocks base method
+func (m *MockParserService_ParseStreamServer) Recv() (*parserproto.ParseStreamRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*parserproto.ParseStreamRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockParserService_ParseStreamServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).Recv))
+}
+
+// SetHeader mocks base method
+func (m *MockParserService_ParseStreamServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockParserService_ParseStreamServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).SetHeader), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockParserService_ParseStreamServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockParserService_ParseStreamServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).SendHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockParserService_ParseStreamServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockParserService_ParseStreamServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).SetTrailer), arg0)
+}
+
+// Context mocks base method
+func (m *MockParserService_ParseStreamServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockParserService_ParseStreamServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).Context))
+}
+
+// SendMsg mocks base method
+func (m_2 *MockParserService_ParseStreamServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockParserService_ParseStreamServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).SendMsg), m)
+}
+
+// RecvMsg mocks base method
+func (m_2 *MockParserService_ParseStreamServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockParserService_ParseStreamServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockParserService_ParseStreamServer)(nil).RecvMsg), m)
+}
+
+// MockParserService_ParseProgressiveServer is a mock of ParserService_ParseProgressiveServer interface
+type MockParserService_ParseProgressiveServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserService_ParseProgressiveServerMockRecorder
+}
+
+// MockParserService_ParseProgressiveServerMockRecorder is the mock recorder for MockParserService_ParseProgressiveServer
+type MockParserService_ParseProgressiveServerMockRecorder struct {
+	mock *MockParserService_ParseProgressiveServer
+}
+
+// NewMockParserService_ParseProgressiveServer creates a new mock instance
+func NewMockParserService_ParseProgressiveServer(ctrl *gomock.Controller) *MockParserService_ParseProgressiveServer {
+	mock := &MockParserService_ParseProgressiveServer{ctrl: ctrl}
+	mock.recorder = &MockParserService_ParseProgressiveServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockParserService_ParseProgressiveServer) EXPECT() *MockParserService_ParseProgressiveServerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method
+func (m *MockParserService_ParseProgressiveServer) Send(arg0 *parserproto.ParseProgressResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).Send), arg0)
+}
+
+// SetHeader mocks base method
+func (m *MockParserService_ParseProgressiveServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).SetHeader), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockParserService_ParseProgressiveServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).SendHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockParserService_ParseProgressiveServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).SetTrailer), arg0)
+}
+
+// Context mocks base method
+func (m *MockParserService_ParseProgressiveServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).Context))
+}
+
+// SendMsg mocks base method
+func (m_2 *MockParserService_ParseProgressiveServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).SendMsg), m)
+}
+
+// RecvMsg mocks base method
+func (m_2 *MockParserService_ParseProgressiveServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockParserService_ParseProgressiveServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockParserService_ParseProgressiveServer)(nil).RecvMsg), m)
+}
+
+// SubmitParse mocks base method
+func (m *MockParserServiceServer) SubmitParse(arg0 context.Context, arg1 *parserproto.ParserRequest) (*parserproto.ParseJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitParse", arg0, arg1)
+	ret0, _ := ret[0].(*parserproto.ParseJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitParse indicates an expected call of SubmitParse
+func (mr *MockParserServiceServerMockRecorder) SubmitParse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitParse", reflect.TypeOf((*MockParserServiceServer)(nil).SubmitParse), arg0, arg1)
+}
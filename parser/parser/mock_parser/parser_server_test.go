@@ -1,279 +1,33 @@
 package mock_parser
 
 import (
+	"bytes"
 	"context"
-	"fmt"
-	"github.com/PuerkitoBio/goquery"
+	"io"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
-	"net/url"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"parser/parser/extractor"
 	pb "parser/parser/parserproto"
+	"parser/parser/server"
 )
 
-type parser_server struct{}
-
-func (ps *parser_server) Parse(ctx context.Context, input *pb.ParserRequest) (*pb.ParserResponse, error) {
-	title, imgUrl, content, err := processHTML(input.Url)
-	fmt.Println(title, "-", imgUrl, "-", content, "-", err)
-	return &pb.ParserResponse{Title: title, ThumbnailUrl: imgUrl, Content: content}, err
-}
-
-func processHTML(inputUrl string) (string, string, string, error) {
-	// Check URL validity
-	_, err := url.ParseRequestURI(inputUrl)
-	if err != nil {
-		log.Println(err)
-		return "", "", "", err
-	}
-
-	// HTTP Request
-	response, err := http.Get(inputUrl)
-	if err != nil {
-		log.Println(err)
-		return "", "", "", err
-	}
-	defer response.Body.Close()
-
-	// Create a goquery document from the HTTP response
-	document, err := goquery.NewDocumentFromReader(response.Body)
-	if err != nil {
-		log.Println("Error loading HTTP response body", err)
-		return "", "", "", err
-	}
-
-	title := getTitle(*document)
-	fmt.Println("Title: " + title)
-	imgUrl := getThumbnailImage(*document)
-	fmt.Println("ImageURL: " + imgUrl)
-	content := getContent(*document)
-	fmt.Println("Content: " + content)
-	return title, imgUrl, content, err
-}
-
-func getTitle(document goquery.Document) string {
-	// Get <Title> tag
-	title := document.Find("title").Text()
-
-	// Get all titles tagged with <h1>
-	titlesH1 := make([]string, 0)
-	document.Find("h1").Each(func(index int, item *goquery.Selection) {
-		titlesH1 = append(titlesH1, item.Text())
-	})
-
-	// Get all titles tagged with <h2>
-	titlesH2 := make([]string, 0)
-	document.Find("h2").Each(func(index int, item *goquery.Selection) {
-		titlesH2 = append(titlesH2, item.Text())
-	})
-
-	// Get all titles tagged with <h3>
-	titlesH3 := make([]string, 0)
-	document.Find("h3").Each(func(index int, item *goquery.Selection) {
-		titlesH3 = append(titlesH3, item.Text())
-	})
-
-	// Check whether URL contains <title>.
-	// If title is empty, get first <h1> title.
-	if title == "" {
-		if 0 < len(titlesH1) {
-			title = titlesH1[0]
-		}
-	}
-
-	// Check whether URL contains <title> and/or <h1>
-	// If title is empty, get first <h2> title.
-	if title == "" {
-		if 0 < len(titlesH2) {
-			title = titlesH2[0]
-		}
-	}
-
-	// Check whether URL contains <title>, <h1> and/or <h2>
-	// If title is empty, get first <h3> title.
-	if title == "" {
-		if 0 < len(titlesH3) {
-			title = titlesH3[0]
-		}
-	}
-
-	// If all title related tags are empty, provide a warning string as title.
-	if title == "" {
-		title = "There is no title-related tags found in the given URL!"
-	}
-
-	return title
-}
-
-func getContent(document goquery.Document) string {
-	var sb strings.Builder
-
-	// Content parser for specific to Medium Blog.
-	document.Find(".section-inner.sectionLayout--insetColumn").Each(func(index int, item *goquery.Selection) {
-		item.Contents().Each(func(i int, ctx *goquery.Selection) {
-			tmp := ctx.Text()
-			if tmp != "" && !strings.Contains(tmp, "BlockedUnblockFollowFollowing") {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", i, tmp)
-			}
-		})
-	})
-
-	if sb.String() == "" {
-		// Content parser for specific to BBC News.
-		document.Find(".story-body__inner").Each(func(index int, item *goquery.Selection) {
-			item.ContentsFiltered("p").Each(func(i int, ctx *goquery.Selection) {
-				tmp := ctx.Text()
-				if tmp != "" && !strings.Contains(tmp, "\n") {
-					sb.WriteString(tmp)
-					sb.WriteString(" ")
-					fmt.Printf("%d: %s\n", i, tmp)
-				}
-			})
-		})
-	}
-
-	if sb.String() == "" {
-		// Content parser for specific to Fox News.
-		document.Find(".article-body").Each(func(index int, item *goquery.Selection) {
-			item.ContentsFiltered("p").Each(func(i int, ctx *goquery.Selection) {
-				tmp := ctx.Text()
-				if tmp != "" && !strings.Contains(tmp, "\n") {
-					sb.WriteString(tmp)
-					sb.WriteString(" ")
-					fmt.Printf("%d: %s\n", i, tmp)
-				}
-			})
-		})
-	}
-
-	if sb.String() == "" {
-		// Content parser for general usage.
-		document.Find("body p").Each(func(index int, item *goquery.Selection) {
-			tmp := item.Text()
-			if tmp != "" && !strings.Contains(tmp, "\n") {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", index, tmp)
-			}
-		})
-		document.Find("body ol").Each(func(index int, item *goquery.Selection) {
-			tmp := item.Text()
-			if tmp != "" {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", index, tmp)
-			}
-		})
-		document.Find("body ul").Each(func(index int, item *goquery.Selection) {
-			tmp := item.Text()
-			if tmp != "" {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", index, tmp)
-			}
-		})
-	}
-
-	if sb.String() == "" {
-		sb.WriteString("Input is either empty webpage or its HTML is not parsable with current state of this code!")
-	}
-
-	return strings.TrimSpace(sb.String())
-}
-
-func getThumbnailImage(document goquery.Document) string {
-	imageUrl := ""
-	document.Find("figure img").Each(func(index int, item *goquery.Selection) {
-		tag := item
-		imageUrl, _ = tag.Attr("src")
-	})
-
-	// Get the image with longest <alt> attribute for a specific newspaper (I do not remember for which one).
-	if imageUrl == "" {
-		prevImgAlt := ""
-		document.Find("body article section").Find("img").Each(func(index int, item *goquery.Selection) {
-			tag := item
-			imageAlt, exist := tag.Attr("alt")
-			if exist {
-				if len(prevImgAlt) < len(imageAlt) {
-					imageUrl, _ = tag.Attr("src")
-					prevImgAlt = imageAlt
-				}
-			} else {
-				imageUrl, _ = tag.Attr("src")
-			}
-		})
-	}
-
-	// Get the image with longest <alt> attribute for a specific newspaper (I do not remember for which one).
-	if imageUrl == "" {
-		prevImgAlt := ""
-		document.Find("body div").Find("img").Each(func(index int, item *goquery.Selection) {
-			tag := item
-			imageAlt, exist := tag.Attr("alt")
-			if exist {
-				if len(prevImgAlt) < len(imageAlt) {
-					imageUrl, _ = tag.Attr("src")
-					prevImgAlt = imageAlt
-				}
-			} else {
-				imageUrl, _ = tag.Attr("src")
-			}
-		})
-	}
-
-	// Get the image with longest <alt> attribute for any web page that are not fit to the previous 3 cases.
-	if imageUrl == "" {
-		prevImgAlt := ""
-		document.Find("img").Each(func(i int, item *goquery.Selection) {
-			tag := item
-			imageAlt, exist := tag.Attr("alt")
-			if exist {
-				if len(prevImgAlt) < len(imageAlt) {
-					imageUrl, _ = tag.Attr("src")
-					prevImgAlt = imageAlt
-				}
-			} else {
-				imageUrl, _ = tag.Attr("src")
-			}
-		})
-	}
-
-	if imageUrl == "" {
-		imageUrl = "There is no image-related tags found in the given URL!"
-	}
-
-	return imageUrl
-}
-
-func (ps *parser_server) ParseTest(ctx context.Context, input *pb.ParserTestRequest) (*pb.ParserResponse, error) {
-	title, imgUrl, content, err := processFileHTML(input.FilePath)
-	return &pb.ParserResponse{Title: title, ThumbnailUrl: imgUrl, Content: content}, err
-}
-
-func processFileHTML(inputFileHtml string) (string, string, string, error) {
-	f, err := os.Open(inputFileHtml)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer f.Close()
-	document, err := goquery.NewDocumentFromReader(f)
-
-	title := getTitle(*document)
-	imgUrl := getThumbnailImage(*document)
-	content := getContent(*document)
-	return title, imgUrl, content, err
-}
-
 func Server() {
 	port := ":50050"
 
@@ -283,7 +37,7 @@ func Server() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	s := grpc.NewServer()
-	pb.RegisterParserServiceServer(s, &parser_server{})
+	pb.RegisterParserServiceServer(s, server.NewParserServer(extractor.New()))
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
 	if err := s.Serve(lis); err != nil {
@@ -291,6 +45,20 @@ func Server() {
 	}
 }
 
+// newClient connects to the test Server. The connection is closed when the test ends.
+func newClient(t *testing.T) (pb.ParserServiceClient, context.Context) {
+	conn, err := grpc.Dial("localhost:50050", grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("did not connect: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(10)*time.Second)
+	t.Cleanup(func() {
+		cancel()
+		conn.Close()
+	})
+	return pb.NewParserServiceClient(conn), ctx
+}
+
 func TestMain(m *testing.M) {
 	go Server()
 	os.Exit(m.Run())
@@ -319,14 +87,14 @@ func TestParse(t *testing.T) {
 		{
 			path:          "./test_urls/test_url1.html",
 			wantTitle:     "Test Page1!",
-			wantThumbnail: "There is no image-related tags found in the given URL!",
-			wantContent:   "Input is either empty webpage or its HTML is not parsable with current state of this code!",
+			wantThumbnail: "",
+			wantContent:   "",
 		},
 		{
 			path:          "./test_urls/test_url2.html",
 			wantTitle:     "Test Page2!",
 			wantThumbnail: "3.jpg",
-			wantContent:   "Input is either empty webpage or its HTML is not parsable with current state of this code!",
+			wantContent:   "",
 		},
 		{
 			path:          "./test_urls/test_url3.html",
@@ -342,21 +110,22 @@ func TestParse(t *testing.T) {
 		},
 		{
 			path:          "./test_urls/test_url5.html",
-			wantTitle:     "There is no title-related tags found in the given URL!",
-			wantThumbnail: "There is no image-related tags found in the given URL!",
-			wantContent:   "Input is either empty webpage or its HTML is not parsable with current state of this code!",
+			wantTitle:     "",
+			wantThumbnail: "",
+			wantContent:   "",
 		},
 		{
 			path:          "./test_urls/test_url6.html",
-			wantTitle:     "There is no title-related tags found in the given URL!",
-			wantThumbnail: "There is no image-related tags found in the given URL!",
-			wantContent:   "Input is either empty webpage or its HTML is not parsable with current state of this code!",
+			wantTitle:     "",
+			wantThumbnail: "",
+			wantContent:   "",
 		},
 		{
-			path:          "./test_urls/test_url7.html",
-			wantTitle:     "Test Page7!",
-			wantThumbnail: "3.jpg",
-			wantContent:   "Input is either empty webpage or its HTML is not parsable with current state of this code!",
+			path:      "./test_urls/test_url7.html",
+			wantTitle: "Test Page7!",
+			// None of the images has a ranking signal, the first one wins.
+			wantThumbnail: "1.jpg",
+			wantContent:   "",
 		},
 	}
 
@@ -378,6 +147,973 @@ func TestParse(t *testing.T) {
 			if r.Content != tt.wantContent {
 				t.Errorf("Expected '%s', got %s", tt.wantContent, r.Content)
 			}
+			if r.HasTitle != (tt.wantTitle != "") || r.HasThumbnail != (tt.wantThumbnail != "") || r.HasContent != (tt.wantContent != "") {
+				t.Errorf("Unexpected presence flags %v %v %v", r.HasTitle, r.HasThumbnail, r.HasContent)
+			}
+		})
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url8.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.Description != "A page with metadata." {
+		t.Errorf("Expected description, got %s", r.Description)
+	}
+	if r.SiteName != "Test Site" {
+		t.Errorf("Expected site name, got %s", r.SiteName)
+	}
+	if strings.Join(r.Authors, ",") != "Jane Doe,John Doe" {
+		t.Errorf("Expected authors, got %v", r.Authors)
+	}
+	if r.PublishedTime.GetSeconds() != time.Date(2018, 12, 20, 10, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("Expected published time, got %v", r.PublishedTime)
+	}
+	if r.ModifiedTime.GetSeconds() != time.Date(2018, 12, 25, 0, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("Expected modified time, got %v", r.ModifiedTime)
+	}
+	if r.Language != "en" {
+		t.Errorf("Expected language, got %s", r.Language)
+	}
+	if strings.Join(r.Keywords, ",") != "go,grpc,parser" {
+		t.Errorf("Expected keywords, got %v", r.Keywords)
+	}
+	if r.CanonicalUrl != "https://example.com/test-page8" {
+		t.Errorf("Expected canonical url, got %s", r.CanonicalUrl)
+	}
+	if r.FaviconUrl != "/favicon.ico" {
+		t.Errorf("Expected favicon url, got %s", r.FaviconUrl)
+	}
+	if r.AmpUrl != "https://example.com/amp/test-page8" {
+		t.Errorf("Expected AMP url, got %s", r.AmpUrl)
+	}
+	wantFeeds := []*pb.AlternateLink{
+		{Url: "https://example.com/feed.xml", Type: "application/rss+xml", Title: "Test Site RSS"},
+		{Url: "/atom.xml", Type: "application/atom+xml"},
+	}
+	if len(r.Feeds) != len(wantFeeds) || !proto.Equal(r.Feeds[0], wantFeeds[0]) || !proto.Equal(r.Feeds[1], wantFeeds[1]) {
+		t.Errorf("Expected feeds %v, got %v", wantFeeds, r.Feeds)
+	}
+	wantOEmbed := &pb.AlternateLink{Url: "https://example.com/oembed?url=https%3A%2F%2Fexample.com%2Ftest-page8&format=json", Type: "application/json+oembed", Title: "Test Page8"}
+	if len(r.OembedEndpoints) != 1 || !proto.Equal(r.OembedEndpoints[0], wantOEmbed) {
+		t.Errorf("Expected oEmbed endpoint %v, got %v", wantOEmbed, r.OembedEndpoints)
+	}
+	if r.WordCount != 6 {
+		t.Errorf("Expected 6 words, got %d", r.WordCount)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	c, ctx := newClient(t)
+	_, err := c.Parse(ctx, &pb.ParserRequest{Url: "not a url"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s, got %v", codes.InvalidArgument, err)
+	}
+	if details := status.Convert(err).Details(); len(details) != 1 {
+		t.Errorf("Expected error details, got %v", details)
+	}
+
+	_, err = c.Parse(ctx, &pb.ParserRequest{Url: "ftp://localhost/file"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s, got %v", codes.InvalidArgument, err)
+	}
+
+	_, err = c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/does_not_exist.html"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected %s, got %v", codes.NotFound, err)
+	}
+}
+
+func TestParseOpenGraph(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url9.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.Title != "Test Page9!" {
+		t.Errorf("Expected og:title, got %s", r.Title)
+	}
+	if r.ThumbnailUrl != "https://example.com/og.jpg" {
+		t.Errorf("Expected og:image, got %s", r.ThumbnailUrl)
+	}
+	if r.Description != "Open Graph description." {
+		t.Errorf("Expected og:description, got %s", r.Description)
+	}
+	if r.OpenGraph.GetType() != "article" || len(r.OpenGraph.GetImages()) != 2 {
+		t.Errorf("Unexpected Open Graph %v", r.OpenGraph)
+	}
+	if r.TwitterCard.GetCard() != "summary_large_image" || r.TwitterCard.GetSite() != "@testsite" || r.TwitterCard.GetImage() != "https://example.com/twitter.jpg" {
+		t.Errorf("Unexpected Twitter Card %v", r.TwitterCard)
+	}
+
+	// Pages without Open Graph tags have no OpenGraph message.
+	r, err = c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url3.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.OpenGraph != nil || r.TwitterCard != nil {
+		t.Errorf("Expected no Open Graph and Twitter Card, got %v %v", r.OpenGraph, r.TwitterCard)
+	}
+}
+
+func TestParseJSONLD(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url10.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.Title != "Test Page10!" {
+		t.Errorf("Expected JSON-LD headline, got %s", r.Title)
+	}
+	if r.ThumbnailUrl != "https://example.com/jsonld.jpg" {
+		t.Errorf("Expected JSON-LD image, got %s", r.ThumbnailUrl)
+	}
+	if r.Content != "Stuff from the article body." {
+		t.Errorf("Expected JSON-LD article body, got %s", r.Content)
+	}
+	if strings.Join(r.Authors, ",") != "Jane Doe,John Doe" {
+		t.Errorf("Expected JSON-LD authors, got %v", r.Authors)
+	}
+	if r.PublishedTime.GetSeconds() != time.Date(2018, 12, 20, 7, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("Expected JSON-LD published time, got %v", r.PublishedTime)
+	}
+	if r.SiteName != "Test Site" || r.StructuredData.GetType() != "NewsArticle" {
+		t.Errorf("Unexpected structured data %v", r.StructuredData)
+	}
+	// The invalid block is skipped.
+	if len(r.JsonLd) != 1 {
+		t.Errorf("Expected 1 JSON-LD block, got %d", len(r.JsonLd))
+	}
+}
+
+func TestParseSiteExtractor(t *testing.T) {
+	c, ctx := newClient(t)
+	tests := []struct {
+		path          string
+		wantExtractor string
+		wantContent   string
+	}{
+		{
+			path:          "./test_urls/test_url11.html",
+			wantExtractor: "bbc",
+			wantContent:   "Stuff to p1 Stuff to p2",
+		},
+		{
+			path:          "./test_urls/test_url3.html",
+			wantExtractor: "generic",
+			wantContent:   "Stuff to p1 Stuff to p2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: tt.path})
+			if err != nil {
+				t.Fatalf("Could not parse: %v", err)
+			}
+			if r.Extractor != tt.wantExtractor {
+				t.Errorf("Expected '%s', got %s", tt.wantExtractor, r.Extractor)
+			}
+			if r.Content != tt.wantContent {
+				t.Errorf("Expected '%s', got %s", tt.wantContent, r.Content)
+			}
 		})
 	}
 }
+
+func TestParseReadability(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url12.html", ContentMode: pb.ContentMode_CONTENT_MODE_READABILITY})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	want := "First section This is the first paragraph of the article, long enough to be scored as content. " +
+		"A list in the middle of the article This is the second paragraph of the article, with a comma, and another one, too."
+	if r.Content != want {
+		t.Errorf("Expected '%s', got %s", want, r.Content)
+	}
+	if r.Extractor != "readability" {
+		t.Errorf("Expected readability extractor, got %s", r.Extractor)
+	}
+	// The blocks are the same as the content.
+	if len(r.Blocks) != 4 || r.Blocks[0].Type != pb.ContentBlock_HEADING || r.Blocks[2].Type != pb.ContentBlock_LIST {
+		t.Errorf("Unexpected blocks %v", r.Blocks)
+	}
+
+	// Pages without any content fall back to the default mode.
+	r, err = c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url1.html", ContentMode: pb.ContentMode_CONTENT_MODE_READABILITY})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.Content != "" || r.Extractor != "generic" {
+		t.Errorf("Expected no content from the generic extractor, got %s %s", r.Content, r.Extractor)
+	}
+}
+
+func TestParseBlocks(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url13.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	// The flat content and the blocks are in document order. The <h1> is the title, and text
+	// outside of the content elements is not content.
+	if r.Content != "Stuff to p1 First item Second item Stuff to p2 A quote func main() { fmt.Println(\"Hello\") } Name Value a 1" {
+		t.Errorf("Unexpected content %s", r.Content)
+	}
+	want := []*pb.ContentBlock{
+		{Type: pb.ContentBlock_PARAGRAPH, Text: "Stuff to p1"},
+		{Type: pb.ContentBlock_LIST, Ordered: true, Items: []string{"First item", "Second item"}},
+		{Type: pb.ContentBlock_PARAGRAPH, Text: "Stuff to p2"},
+		{Type: pb.ContentBlock_BLOCKQUOTE, Text: "A quote"},
+		{Type: pb.ContentBlock_CODE, Text: "func main() {\n\tfmt.Println(\"Hello\")\n}"},
+		{Type: pb.ContentBlock_TABLE, Rows: []*pb.TableRow{{Cells: []string{"Name", "Value"}}, {Cells: []string{"a", "1"}}}},
+	}
+	if len(r.Blocks) != len(want) {
+		t.Fatalf("Expected %d blocks, got %v", len(want), r.Blocks)
+	}
+	for i := range want {
+		if !proto.Equal(r.Blocks[i], want[i]) {
+			t.Errorf("Block %d: expected %v, got %v", i, want[i], r.Blocks[i])
+		}
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{
+		FilePath:       "./test_urls/test_url14.html",
+		ContentFormats: []pb.ContentFormat{pb.ContentFormat_CONTENT_FORMAT_MARKDOWN, pb.ContentFormat_CONTENT_FORMAT_HTML},
+	})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	markdown := "## Hello *World*!\n\nRead the [docs](https://example.com/docs) and this.\n\n- First **item**\n- Second item\n\n![An image](https://example.com/image.png)" +
+		"\n\n&lt;img src=x onerror=alert(1)&gt; &amp; more\n\n\\# Heading?\n\n1\\. not a list\\\n\\- nor this" +
+		"\n\n![Lazy](https://example.com/lazy.png)"
+	if r.ContentMarkdown != markdown {
+		t.Errorf("Expected markdown %q, got %q", markdown, r.ContentMarkdown)
+	}
+	for _, unsafe := range []string{"script", "steal", "style", "onclick"} {
+		if strings.Contains(r.ContentHtml, unsafe) {
+			t.Errorf("Expected sanitized HTML without %q, got %s", unsafe, r.ContentHtml)
+		}
+	}
+	for _, safe := range []string{`<h2>Hello <em>World</em>!</h2>`, `<a href="https://example.com/docs" rel="nofollow noopener">docs</a>`, `<li>First <strong>item</strong></li>`, `<img src="https://example.com/image.png" alt="An image"/>`,
+		`<p>&lt;img src=x onerror=alert(1)&gt; &amp; more</p>`, `<img src="https://example.com/lazy.png" alt="Lazy"/>`} {
+		if !strings.Contains(r.ContentHtml, safe) {
+			t.Errorf("Expected sanitized HTML with %s, got %s", safe, r.ContentHtml)
+		}
+	}
+
+	// The formats are only rendered when requested.
+	r, err = c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url14.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.ContentMarkdown != "" || r.ContentHtml != "" {
+		t.Errorf("Expected no formats, got %q %q", r.ContentMarkdown, r.ContentHtml)
+	}
+}
+
+func TestParseThumbnailResolution(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url15.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	// The largest <picture> candidate, resolved against <base href>.
+	if r.ThumbnailUrl != "https://example.com/articles/images/large.webp" {
+		t.Errorf("Expected the largest srcset candidate, got %s", r.ThumbnailUrl)
+	}
+}
+
+func TestParseImageRanking(t *testing.T) {
+	c, ctx := newClient(t)
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url16.html", MaxImages: 4})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.ThumbnailUrl != "https://example.com/photos/harbour.jpg" {
+		t.Errorf("Expected the article photo, got %s", r.ThumbnailUrl)
+	}
+	if len(r.Images) != 4 {
+		t.Fatalf("Expected 4 images, got %v", r.Images)
+	}
+	top := r.Images[0]
+	if top.Url != r.ThumbnailUrl || top.Width != 800 || top.Height != 600 || top.Alt != "The harbour at sunrise" {
+		t.Errorf("Unexpected top image %v", top)
+	}
+	for _, reason := range []string{"og:image", "large (800x600)", "in the main content", "in a figure", "alt text"} {
+		if !contains(top.Reasons, reason) {
+			t.Errorf("Expected reason %q, got %v", reason, top.Reasons)
+		}
+	}
+	for _, image := range r.Images[1:] {
+		if image.Score > top.Score {
+			t.Errorf("Images are not sorted by score: %v", r.Images)
+		}
+	}
+	// The tracking pixel and the logo of the header are ranked last.
+	for i, url := range []string{"https://example.com/track/pixel.gif", "https://example.com/static/logo.png"} {
+		if image := r.Images[2+i]; image.Url != url || image.Score >= 0 {
+			t.Errorf("Expected %s with a negative score, got %v", url, image)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseFetchOptions(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		w.Write([]byte("<html><head><title>" + r.UserAgent() + "</title></head><body><p>Stuff to p1</p></body></html>"))
+	}))
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	r, err := c.Parse(ctx, &pb.ParserRequest{Url: page.URL, FetchOptions: &pb.FetchOptions{UserAgent: "Custom/1.0"}})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.Title != "Custom/1.0" {
+		t.Errorf("Expected the requested User-Agent, got %s", r.Title)
+	}
+
+	_, err = c.Parse(ctx, &pb.ParserRequest{Url: page.URL, FetchOptions: &pb.FetchOptions{MaxBodyBytes: 10}})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected %s, got %v", codes.ResourceExhausted, err)
+	}
+
+	_, err = c.Parse(ctx, &pb.ParserRequest{Url: page.URL + "/slow", FetchOptions: &pb.FetchOptions{ReadTimeout: ptypes.DurationProto(50 * time.Millisecond)}})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected %s, got %v", codes.DeadlineExceeded, err)
+	}
+}
+
+func TestParseDeadline(t *testing.T) {
+	stopped := make(chan struct{})
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never answers, until the server gives up.
+		<-r.Context().Done()
+		close(stopped)
+	}))
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err := c.Parse(ctx, &pb.ParserRequest{Url: page.URL})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected %s, got %v", codes.DeadlineExceeded, err)
+	}
+	// The deadline of the client is propagated to the server, which stops downloading the page.
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Error("Expected the server to stop fetching the page")
+	}
+}
+
+func TestParseCharset(t *testing.T) {
+	c, ctx := newClient(t)
+	// A windows-1254 page without charset declaration.
+	r, err := c.ParseTest(ctx, &pb.ParserTestRequest{FilePath: "./test_urls/test_url17.html"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.Charset != "windows-1254" || r.Title != "Türkçe Sayfa" || r.Content != "Türkiye'nin başkenti Ankara, en kalabalık şehri İstanbul'dur." {
+		t.Errorf("Unexpected charset, title or content: %s %s %s", r.Charset, r.Title, r.Content)
+	}
+}
+
+func TestParseContentTypes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("Stuff to p1\nStuff to p2"))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`))
+	})
+	mux.HandleFunc("/pdf", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "./test_urls/test_url18.pdf")
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"title": "JSON"}`))
+	})
+	mux.HandleFunc("/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+	page := httptest.NewServer(mux)
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	r, err := c.Parse(ctx, &pb.ParserRequest{Url: page.URL + "/text"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.ContentType != "text/plain" || r.Extractor != "text" || r.Content != "Stuff to p1 Stuff to p2" || len(r.Blocks) != 2 {
+		t.Errorf("Unexpected text page %v", r)
+	}
+
+	r, err = c.Parse(ctx, &pb.ParserRequest{Url: page.URL + "/image"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.ContentType != "image/svg+xml" || r.Extractor != "image" || r.ThumbnailUrl != page.URL+"/image" || !r.HasThumbnail {
+		t.Errorf("Unexpected image page %v", r)
+	}
+
+	r, err = c.Parse(ctx, &pb.ParserRequest{Url: page.URL + "/pdf"})
+	if err != nil {
+		t.Fatalf("Could not parse: %v", err)
+	}
+	if r.ContentType != "application/pdf" || r.Extractor != "pdf" || r.Title != "Rapor Özeti" || r.PageCount != 2 {
+		t.Errorf("Unexpected PDF page %v", r)
+	}
+	if len(r.Authors) != 1 || r.Authors[0] != "Jane (J.) Doe" || r.Content != "Annual report Sales grew by 5% HI §" {
+		t.Errorf("Unexpected PDF content %v", r)
+	}
+
+	tests := []struct {
+		path     string
+		wantCode codes.Code
+	}{
+		{path: "/json", wantCode: codes.InvalidArgument},
+		{path: "/missing", wantCode: codes.NotFound},
+		{path: "/forbidden", wantCode: codes.PermissionDenied},
+		{path: "/error", wantCode: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		_, err := c.Parse(ctx, &pb.ParserRequest{Url: page.URL + tt.path})
+		if status.Code(err) != tt.wantCode {
+			t.Errorf("%s: expected %s, got %v", tt.path, tt.wantCode, err)
+		}
+	}
+}
+
+func TestParseFeed(t *testing.T) {
+	page := httptest.NewServer(http.FileServer(http.Dir("./test_urls")))
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	r, err := c.ParseFeed(ctx, &pb.FeedRequest{Url: page.URL + "/test_url19.xml", ParseEntries: true})
+	if err != nil {
+		t.Fatalf("Could not parse feed: %v", err)
+	}
+	if r.Format != "rss" || r.Title != "Test Feed!" || r.Link != page.URL+"/" || len(r.Entries) != 2 {
+		t.Fatalf("Unexpected feed %v", r)
+	}
+	entry := r.Entries[0]
+	if entry.Link != page.URL+"/test_url4.html" || entry.Summary != "Stuff to p1" || entry.ImageUrl != page.URL+"/4.jpg" {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+	if ptypes.TimestampString(entry.Published) != "2020-03-02T08:30:00Z" {
+		t.Errorf("Unexpected published time %v", entry.Published)
+	}
+	if entry.Article == nil || entry.Article.Title != "Test Page4 in h1 tag!" || entry.Article.Content != "Stuff to p1 Stuff to p2" {
+		t.Errorf("Unexpected article %v", entry.Article)
+	}
+	if entry := r.Entries[1]; entry.Article != nil || entry.ArticleError == "" {
+		t.Errorf("Expected an article error, got %v", entry)
+	}
+
+	r, err = c.ParseFeed(ctx, &pb.FeedRequest{Url: page.URL + "/test_url19.xml", MaxEntries: 1})
+	if err != nil || len(r.Entries) != 1 || r.Entries[0].Article != nil {
+		t.Errorf("Unexpected feed %v, %v", r, err)
+	}
+	_, err = c.ParseFeed(ctx, &pb.FeedRequest{Url: page.URL + "/test_url1.html"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s, got %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestBatchParse(t *testing.T) {
+	page := httptest.NewServer(http.FileServer(http.Dir("./test_urls")))
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	urls := []string{page.URL + "/test_url4.html", "not a url", page.URL + "/missing.html", page.URL + "/test_url2.html"}
+	r, err := c.BatchParse(ctx, &pb.BatchParserRequest{Urls: urls, MaxImages: 1})
+	if err != nil {
+		t.Fatalf("Could not parse batch: %v", err)
+	}
+	if len(r.Results) != len(urls) {
+		t.Fatalf("Expected %d results, got %d", len(urls), len(r.Results))
+	}
+	wantCodes := []codes.Code{codes.OK, codes.InvalidArgument, codes.NotFound, codes.OK}
+	for i, result := range r.Results {
+		if result.Url != urls[i] || codes.Code(result.Code) != wantCodes[i] {
+			t.Errorf("%s: expected %s, got %s %s", urls[i], wantCodes[i], codes.Code(result.Code), result.Error)
+		}
+		if (result.Response != nil) != (wantCodes[i] == codes.OK) || (result.Error == "") != (wantCodes[i] == codes.OK) {
+			t.Errorf("%s: unexpected result %v", urls[i], result)
+		}
+	}
+	if r := r.Results[0].Response; r.Title != "Test Page4 in h1 tag!" || r.Content != "Stuff to p1 Stuff to p2" || len(r.Images) != 1 {
+		t.Errorf("Unexpected response %v", r)
+	}
+
+	_, err = c.BatchParse(ctx, &pb.BatchParserRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s for an empty batch, got %v", codes.InvalidArgument, err)
+	}
+	_, err = c.BatchParse(ctx, &pb.BatchParserRequest{Urls: make([]string, server.DefaultMaxBatchSize+1)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s for a too large batch, got %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte("<html><head><title>Slow</title></head></html>"))
+	})
+	mux.HandleFunc("/fast", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><title>Fast</title></head></html>"))
+	})
+	page := httptest.NewServer(mux)
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	stream, err := c.ParseStream(ctx)
+	if err != nil {
+		t.Fatalf("Could not open the stream: %v", err)
+	}
+	requests := []*pb.ParseStreamRequest{
+		{Id: "slow", Request: &pb.ParserRequest{Url: page.URL + "/slow"}},
+		{Id: "fast", Request: &pb.ParserRequest{Url: page.URL + "/fast"}},
+		{Id: "missing", Request: &pb.ParserRequest{Url: page.URL + "/missing"}},
+		{Id: "empty"},
+	}
+	for _, request := range requests {
+		if err := stream.Send(request); err != nil {
+			t.Fatalf("Could not send %s: %v", request.Id, err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("Could not close the stream: %v", err)
+	}
+
+	var order []string
+	results := make(map[string]*pb.BatchParserResult)
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Could not receive: %v", err)
+		}
+		order = append(order, response.Id)
+		results[response.Id] = response.Result
+	}
+	if len(order) != len(requests) || order[len(order)-1] != "slow" {
+		t.Errorf("Expected the slow page last, got %v", order)
+	}
+	if r := results["fast"]; r.GetResponse().GetTitle() != "Fast" || codes.Code(r.GetCode()) != codes.OK {
+		t.Errorf("Unexpected fast result %v", r)
+	}
+	if r := results["slow"]; r.GetResponse().GetTitle() != "Slow" {
+		t.Errorf("Unexpected slow result %v", r)
+	}
+	if r := results["missing"]; codes.Code(r.GetCode()) != codes.NotFound || r.GetResponse() != nil {
+		t.Errorf("Unexpected missing result %v", r)
+	}
+	if r := results["empty"]; codes.Code(r.GetCode()) != codes.InvalidArgument {
+		t.Errorf("Unexpected empty result %v", r)
+	}
+}
+
+func TestParseProgressive(t *testing.T) {
+	page := httptest.NewServer(http.FileServer(http.Dir("./test_urls")))
+	defer page.Close()
+
+	receive := func(ctx context.Context, c pb.ParserServiceClient, url string) ([]*pb.ParseProgressResponse, error) {
+		stream, err := c.ParseProgressive(ctx, &pb.ParserRequest{Url: url})
+		if err != nil {
+			return nil, err
+		}
+		var updates []*pb.ParseProgressResponse
+		for {
+			update, err := stream.Recv()
+			if err == io.EOF {
+				return updates, nil
+			}
+			if err != nil {
+				return updates, err
+			}
+			updates = append(updates, update)
+		}
+	}
+
+	c, ctx := newClient(t)
+	updates, err := receive(ctx, c, page.URL+"/test_url4.html")
+	if err != nil {
+		t.Fatalf("Could not parse progressively: %v", err)
+	}
+	var stages []pb.ParseStage
+	for _, update := range updates {
+		stages = append(stages, update.Stage)
+	}
+	wantStages := []pb.ParseStage{pb.ParseStage_PARSE_STAGE_FETCHED, pb.ParseStage_PARSE_STAGE_METADATA,
+		pb.ParseStage_PARSE_STAGE_THUMBNAIL, pb.ParseStage_PARSE_STAGE_CONTENT}
+	if !reflect.DeepEqual(stages, wantStages) {
+		t.Fatalf("Expected stages %v, got %v", wantStages, stages)
+	}
+	if r := updates[0].Response; r.ContentType != "text/html" || r.Title != "" {
+		t.Errorf("Unexpected fetched response %v", r)
+	}
+	if r := updates[1].Response; r.Title != "Test Page4 in h1 tag!" || r.ThumbnailUrl != "" || r.Content != "" {
+		t.Errorf("Unexpected metadata response %v", r)
+	}
+	if r := updates[2].Response; r.ThumbnailUrl != page.URL+"/3.jpg" || r.Content != "" {
+		t.Errorf("Unexpected thumbnail response %v", r)
+	}
+	if r := updates[3].Response; r.Title != "Test Page4 in h1 tag!" || r.Content != "Stuff to p1 Stuff to p2" {
+		t.Errorf("Unexpected complete response %v", r)
+	}
+
+	updates, err = receive(ctx, c, page.URL+"/missing.html")
+	if status.Code(err) != codes.NotFound || len(updates) != 0 {
+		t.Errorf("Expected %s and no update, got %v %v", codes.NotFound, updates, err)
+	}
+}
+
+// waitParseJob polls a job until done returns true for its state.
+func waitParseJob(t *testing.T, ctx context.Context, c pb.ParserServiceClient, id string, done func(pb.JobState) bool) *pb.ParseJob {
+	for {
+		job, err := c.GetParseJob(ctx, &pb.GetParseJobRequest{Id: id})
+		if err != nil {
+			t.Fatalf("Could not get job %s: %v", id, err)
+		}
+		if done(job.State) {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestParseJobs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("./test_urls")))
+	mux.HandleFunc("/hang", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	page := httptest.NewServer(mux)
+	defer page.Close()
+
+	c, ctx := newClient(t)
+	submitted, err := c.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/test_url4.html"})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	if submitted.Id == "" || submitted.CreatedTime == nil || submitted.Response != nil {
+		t.Errorf("Unexpected submitted job %v", submitted)
+	}
+	job := waitParseJob(t, ctx, c, submitted.Id, func(state pb.JobState) bool { return state == pb.JobState_JOB_STATE_SUCCEEDED })
+	if job.Response.GetTitle() != "Test Page4 in h1 tag!" || job.StartedTime == nil || job.FinishedTime == nil || codes.Code(job.Code) != codes.OK {
+		t.Errorf("Unexpected succeeded job %v", job)
+	}
+	if _, err := c.CancelParseJob(ctx, &pb.CancelParseJobRequest{Id: job.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected %s for a finished job, got %v", codes.FailedPrecondition, err)
+	}
+
+	failed, err := c.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/missing.html"})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	job = waitParseJob(t, ctx, c, failed.Id, func(state pb.JobState) bool { return state == pb.JobState_JOB_STATE_FAILED })
+	if codes.Code(job.Code) != codes.NotFound || job.Error == "" || job.Response != nil {
+		t.Errorf("Unexpected failed job %v", job)
+	}
+
+	hanging, err := c.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/hang"})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	waitParseJob(t, ctx, c, hanging.Id, func(state pb.JobState) bool { return state == pb.JobState_JOB_STATE_RUNNING })
+	job, err = c.CancelParseJob(ctx, &pb.CancelParseJobRequest{Id: hanging.Id})
+	if err != nil || job.State != pb.JobState_JOB_STATE_CANCELLED || codes.Code(job.Code) != codes.Canceled {
+		t.Errorf("Unexpected cancelled job %v %v", job, err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if job := waitParseJob(t, ctx, c, hanging.Id, func(pb.JobState) bool { return true }); job.State != pb.JobState_JOB_STATE_CANCELLED {
+		t.Errorf("Expected the job to stay cancelled, got %v", job)
+	}
+
+	// The jobs of the previous runs of the test are also listed.
+	submittedIds := map[string]bool{submitted.Id: true, failed.Id: true, hanging.Id: true}
+	var ids []string
+	var pageToken string
+	for {
+		list, err := c.ListParseJobs(ctx, &pb.ListParseJobsRequest{PageSize: 2, PageToken: pageToken})
+		if err != nil {
+			t.Fatalf("Could not list jobs: %v", err)
+		}
+		for _, job := range list.Jobs {
+			if submittedIds[job.Id] {
+				ids = append(ids, job.Id)
+			}
+			if job.Response != nil {
+				t.Errorf("Expected no response in the list, got %v", job)
+			}
+		}
+		if pageToken = list.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+	if want := []string{submitted.Id, failed.Id, hanging.Id}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected jobs %v, got %v", want, ids)
+	}
+	list, err := c.ListParseJobs(ctx, &pb.ListParseJobsRequest{State: pb.JobState_JOB_STATE_FAILED})
+	if err != nil {
+		t.Fatalf("Could not list jobs: %v", err)
+	}
+	ids = nil
+	for _, job := range list.Jobs {
+		if job.State != pb.JobState_JOB_STATE_FAILED {
+			t.Errorf("Expected only failed jobs, got %v", job)
+		}
+		if submittedIds[job.Id] {
+			ids = append(ids, job.Id)
+		}
+	}
+	if want := []string{failed.Id}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected failed jobs %v, got %v", want, ids)
+	}
+
+	if _, err := c.GetParseJob(ctx, &pb.GetParseJobRequest{Id: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected %s, got %v", codes.NotFound, err)
+	}
+	if _, err := c.ListParseJobs(ctx, &pb.ListParseJobsRequest{PageToken: "token"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s, got %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestParseJobLimits(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer page.Close()
+
+	ctx := context.Background()
+	ps := server.NewParserServer(extractor.New(), server.WithJobLimits(1, 1, 50*time.Millisecond))
+	defer ps.Stop()
+	var jobs []*pb.ParseJob
+	for len(jobs) < 2 {
+		job, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL})
+		if err != nil {
+			t.Fatalf("Could not submit: %v", err)
+		}
+		jobs = append(jobs, job)
+		// The first job leaves the queue once it runs.
+		for job.State == pb.JobState_JOB_STATE_QUEUED && len(jobs) == 1 {
+			time.Sleep(10 * time.Millisecond)
+			job, _ = ps.GetParseJob(ctx, &pb.GetParseJobRequest{Id: job.Id})
+		}
+	}
+	if _, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected %s for a full queue, got %v", codes.ResourceExhausted, err)
+	}
+
+	// A cancelled job frees its place in the queue.
+	if _, err := ps.CancelParseJob(ctx, &pb.CancelParseJobRequest{Id: jobs[1].Id}); err != nil {
+		t.Errorf("Could not cancel %s: %v", jobs[1].Id, err)
+	}
+	job, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL})
+	if err != nil {
+		t.Fatalf("Could not submit after a cancellation: %v", err)
+	}
+	jobs = append(jobs, job)
+
+	ps.Stop()
+	for _, job := range jobs {
+		job, err := ps.GetParseJob(ctx, &pb.GetParseJobRequest{Id: job.Id})
+		if err != nil || job.State != pb.JobState_JOB_STATE_CANCELLED {
+			t.Errorf("Expected a cancelled job once stopped, got %v %v", job, err)
+		}
+	}
+	if _, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected %s once stopped, got %v", codes.Unavailable, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	for _, job := range jobs {
+		if _, err := ps.GetParseJob(ctx, &pb.GetParseJobRequest{Id: job.Id}); status.Code(err) != codes.NotFound {
+			t.Errorf("Expected %s after the retention period, got %v", codes.NotFound, err)
+		}
+	}
+}
+
+func TestParseJobWebhooks(t *testing.T) {
+	page := httptest.NewServer(http.FileServer(http.Dir("./test_urls")))
+	defer page.Close()
+
+	secret := []byte("secret")
+	type callback struct {
+		header http.Header
+		body   []byte
+	}
+	callbacks := make(chan callback, 10)
+	var attempts int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		// The first delivery of every job fails.
+		if r.URL.Query().Get("fail") != "" && atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Query().Get("reject") != "" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		callbacks <- callback{r.Header, body}
+	}))
+	defer receiver.Close()
+
+	ctx := context.Background()
+	ps := server.NewParserServer(extractor.New(), server.WithWebhooks(secret, 3, 10*time.Millisecond))
+	defer ps.Stop()
+	wait := func(id string) *pb.ParseJob {
+		for {
+			job, err := ps.GetParseJob(ctx, &pb.GetParseJobRequest{Id: id})
+			if err != nil {
+				t.Fatalf("Could not get job %s: %v", id, err)
+			}
+			if job.Webhook.GetState() != pb.WebhookState_WEBHOOK_STATE_PENDING {
+				return job
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	job, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/test_url4.html", CallbackUrl: receiver.URL + "?fail=1"})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	if job.Webhook.GetState() != pb.WebhookState_WEBHOOK_STATE_PENDING {
+		t.Errorf("Expected a pending webhook, got %v", job.Webhook)
+	}
+	job = wait(job.Id)
+	if w := job.Webhook; w.State != pb.WebhookState_WEBHOOK_STATE_DELIVERED || w.Attempts != 2 || w.LastStatusCode != http.StatusOK || w.LastAttemptTime == nil {
+		t.Errorf("Unexpected webhook %v", w)
+	}
+	c := <-callbacks
+	if got, want := c.header.Get(server.WebhookSignatureHeader), server.WebhookSignature(secret, c.header.Get(server.WebhookTimestampHeader), c.body); got != want {
+		t.Errorf("Expected the signature %s, got %s", want, got)
+	}
+	if c.header.Get(server.WebhookJobIDHeader) != job.Id || c.header.Get(server.WebhookJobStateHeader) != "JOB_STATE_SUCCEEDED" {
+		t.Errorf("Unexpected headers %v", c.header)
+	}
+	var response pb.ParserResponse
+	if err := jsonpb.Unmarshal(bytes.NewReader(c.body), &response); err != nil || response.Title != "Test Page4 in h1 tag!" {
+		t.Errorf("Unexpected body %s: %v", c.body, err)
+	}
+
+	job, err = ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/missing.html", CallbackUrl: receiver.URL})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	job = wait(job.Id)
+	c = <-callbacks
+	if job.Webhook.State != pb.WebhookState_WEBHOOK_STATE_DELIVERED || c.header.Get(server.WebhookJobStateHeader) != "JOB_STATE_FAILED" ||
+		!strings.Contains(string(c.body), `"code":5`) {
+		t.Errorf("Unexpected failed job callback %v %s", job.Webhook, c.body)
+	}
+
+	job, err = ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/test_url4.html", CallbackUrl: receiver.URL + "?reject=1"})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	if w := wait(job.Id).Webhook; w.State != pb.WebhookState_WEBHOOK_STATE_FAILED || w.Attempts != 1 || w.LastStatusCode != http.StatusGone || w.LastError == "" {
+		t.Errorf("Expected a failed webhook without retries, got %v", w)
+	}
+
+	if _, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL, CallbackUrl: "ftp://example.com"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected %s for an ftp callback, got %v", codes.InvalidArgument, err)
+	}
+	c2, ctx2 := newClient(t)
+	if _, err := c2.SubmitParse(ctx2, &pb.ParserRequest{Url: page.URL, CallbackUrl: receiver.URL}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected %s without a webhook secret, got %v", codes.FailedPrecondition, err)
+	}
+}
+
+func TestParseJobWebhooksStop(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("<html><body><p>Stuff to p1</p></body></html>"))
+	}))
+	defer page.Close()
+	states := make(chan string, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		states <- r.Header.Get(server.WebhookJobStateHeader)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	ctx := context.Background()
+	ps := server.NewParserServer(extractor.New(), server.WithWebhooks([]byte("secret"), 5, time.Minute))
+	defer ps.Stop()
+	retried, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL, CallbackUrl: receiver.URL})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	if state := <-states; state != "JOB_STATE_SUCCEEDED" {
+		t.Errorf("Expected a succeeded job callback, got %s", state)
+	}
+	running, err := ps.SubmitParse(ctx, &pb.ParserRequest{Url: page.URL + "/slow", CallbackUrl: receiver.URL})
+	if err != nil {
+		t.Fatalf("Could not submit: %v", err)
+	}
+	for job := running; job.State != pb.JobState_JOB_STATE_RUNNING; {
+		time.Sleep(10 * time.Millisecond)
+		job, _ = ps.GetParseJob(ctx, &pb.GetParseJobRequest{Id: running.Id})
+	}
+
+	// Stop does not wait for the backoff of the retried callback, but delivers the callback of
+	// the cancelled job before returning.
+	start := time.Now()
+	ps.Stop()
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Stop took %s", elapsed)
+	}
+	select {
+	case state := <-states:
+		if state != "JOB_STATE_CANCELLED" {
+			t.Errorf("Expected a cancelled job callback, got %s", state)
+		}
+	default:
+		t.Errorf("Expected the callback of the cancelled job once stopped")
+	}
+	for _, id := range []string{retried.Id, running.Id} {
+		job, err := ps.GetParseJob(ctx, &pb.GetParseJobRequest{Id: id})
+		if err != nil || job.Webhook.GetState() != pb.WebhookState_WEBHOOK_STATE_FAILED || job.Webhook.Attempts != 1 {
+			t.Errorf("Expected a failed webhook after one attempt, got %v %v", job.GetWebhook(), err)
+		}
+	}
+}
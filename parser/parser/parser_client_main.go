@@ -4,8 +4,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"io"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	pb "parser/parser/parserproto"
@@ -14,6 +20,17 @@ import (
 func main() {
 	serverAddress := flag.String("address", "localhost:50051", "A string argument for IP. Default value is localhost(it directs to 127.0.0.1:80)")
 	inputUrl := flag.String("url", "https://medium.com/jatana/report-on-text-classification-using-cnn-rnn-han-f0e887214d5f", "A string argument for the input URL.")
+	readability := flag.Bool("readability", false, "A boolean argument to detect the main content with the readability mode.")
+	markdown := flag.Bool("markdown", false, "A boolean argument to also get the content as Markdown.")
+	sanitizedHTML := flag.Bool("html", false, "A boolean argument to also get the content as sanitized HTML.")
+	probeImages := flag.Bool("probe-images", false, "A boolean argument to check the type and dimensions of the candidate images.")
+	feed := flag.Bool("feed", false, "A boolean argument to parse the input URL as an RSS, Atom or JSON feed.")
+	parseEntries := flag.Bool("parse-entries", false, "A boolean argument to also parse the entry links of the feed.")
+	batchUrls := flag.String("urls", "", "A string argument for comma separated URLs parsed in one BatchParse call, instead of -url.")
+	streamUrls := flag.Bool("stream", false, "A boolean argument to parse the -urls with a ParseStream call instead of BatchParse.")
+	progressive := flag.Bool("progressive", false, "A boolean argument to print the partial responses of a ParseProgressive call for the input URL.")
+	async := flag.Bool("async", false, "A boolean argument to parse the input URL with a SubmitParse job, polled until it finishes.")
+	callbackUrl := flag.String("callback-url", "", "A string argument for the URL the server POSTs the -async job result to when it finishes.")
 	flag.Parse()
 
 	fmt.Printf("You are connecting to %s\n", *serverAddress)
@@ -30,11 +47,209 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(10)*time.Second)
 	defer cancel()
 
-	r, err := c.Parse(ctx, &pb.ParserRequest{Url: *inputUrl})
+	request := &pb.ParserRequest{Url: *inputUrl, CallbackUrl: *callbackUrl}
+	if *readability {
+		request.ContentMode = pb.ContentMode_CONTENT_MODE_READABILITY
+	}
+	request.ProbeImages = *probeImages
+	if *markdown {
+		request.ContentFormats = append(request.ContentFormats, pb.ContentFormat_CONTENT_FORMAT_MARKDOWN)
+	}
+	if *sanitizedHTML {
+		request.ContentFormats = append(request.ContentFormats, pb.ContentFormat_CONTENT_FORMAT_HTML)
+	}
+	if *batchUrls != "" && *streamUrls {
+		parseStream(ctx, c, strings.Split(*batchUrls, ","), request)
+		return
+	}
+	if *batchUrls != "" {
+		batchParse(ctx, c, &pb.BatchParserRequest{
+			Urls:           strings.Split(*batchUrls, ","),
+			ContentMode:    request.ContentMode,
+			ContentFormats: request.ContentFormats,
+			ProbeImages:    request.ProbeImages,
+		})
+		return
+	}
+	if *async {
+		submitParse(c, request)
+		return
+	}
+	if *progressive {
+		parseProgressive(ctx, c, request)
+		return
+	}
+	if *feed {
+		parseFeed(ctx, c, &pb.FeedRequest{
+			Url:            request.Url,
+			ParseEntries:   *parseEntries,
+			ContentMode:    request.ContentMode,
+			ContentFormats: request.ContentFormats,
+			ProbeImages:    request.ProbeImages,
+		})
+		return
+	}
+	r, err := c.Parse(ctx, request)
 	if err != nil {
 		log.Fatalf("could not parse: %v", err)
 	}
 	log.Printf("Parsed Title: %s", r.Title)
 	log.Printf("Parsed Thumbnail Image URL: %s", r.ThumbnailUrl)
 	log.Printf("Parsed Content: %s", r.Content)
+	log.Printf("Parsed Description: %s", r.Description)
+	log.Printf("Parsed Canonical URL: %s", r.CanonicalUrl)
+	log.Printf("Parsed Site Name: %s", r.SiteName)
+	log.Printf("Parsed Authors: %s", strings.Join(r.Authors, ", "))
+	log.Printf("Parsed Published Time: %s", ptypes.TimestampString(r.PublishedTime))
+	log.Printf("Parsed Modified Time: %s", ptypes.TimestampString(r.ModifiedTime))
+	log.Printf("Parsed Language: %s", r.Language)
+	log.Printf("Parsed Keywords: %s", strings.Join(r.Keywords, ", "))
+	log.Printf("Parsed Favicon URL: %s", r.FaviconUrl)
+	log.Printf("Parsed Word Count: %d", r.WordCount)
+	if r.AmpUrl != "" {
+		log.Printf("Parsed AMP URL: %s", r.AmpUrl)
+	}
+	for _, feed := range r.Feeds {
+		log.Printf("Discovered Feed: %s (%s) %s", feed.Url, feed.Type, feed.Title)
+	}
+	for _, endpoint := range r.OembedEndpoints {
+		log.Printf("Discovered oEmbed Endpoint: %s (%s)", endpoint.Url, endpoint.Type)
+	}
+	if *markdown {
+		log.Printf("Parsed Content (Markdown):\n%s", r.ContentMarkdown)
+	}
+	if *sanitizedHTML {
+		log.Printf("Parsed Content (HTML):\n%s", r.ContentHtml)
+	}
+	if p := r.ThumbnailProbe; p != nil {
+		log.Printf("Parsed Thumbnail Image: %s %dx%d", p.ContentType, p.Width, p.Height)
+	}
+	for _, image := range r.Images {
+		log.Printf("Image Candidate: %s (score %.1f: %s)", image.Url, image.Score, strings.Join(image.Reasons, ", "))
+	}
+	log.Printf("Final URL: %s", r.FinalUrl)
+	log.Printf("Extractor: %s", r.Extractor)
+}
+
+func parseFeed(ctx context.Context, c pb.ParserServiceClient, request *pb.FeedRequest) {
+	r, err := c.ParseFeed(ctx, request)
+	if err != nil {
+		log.Fatalf("could not parse feed: %v", err)
+	}
+	log.Printf("Feed (%s): %s - %s", r.Format, r.Title, r.Link)
+	log.Printf("Feed Description: %s", r.Description)
+	log.Printf("Feed Updated: %s", ptypes.TimestampString(r.Updated))
+	for _, entry := range r.Entries {
+		log.Printf("Entry: %s - %s (%s)", entry.Title, entry.Link, ptypes.TimestampString(entry.Published))
+		log.Printf("Entry Summary: %s", entry.Summary)
+		if entry.ImageUrl != "" {
+			log.Printf("Entry Image URL: %s", entry.ImageUrl)
+		}
+		if entry.Article != nil {
+			log.Printf("Entry Article: %s (%d words)", entry.Article.Title, entry.Article.WordCount)
+		}
+		if entry.ArticleError != "" {
+			log.Printf("Entry Article Error: %s", entry.ArticleError)
+		}
+	}
+}
+
+func batchParse(ctx context.Context, c pb.ParserServiceClient, request *pb.BatchParserRequest) {
+	r, err := c.BatchParse(ctx, request)
+	if err != nil {
+		log.Fatalf("could not parse batch: %v", err)
+	}
+	for _, result := range r.Results {
+		if result.Response == nil {
+			log.Printf("%s: %s (%s)", result.Url, codes.Code(result.Code), result.Error)
+			continue
+		}
+		log.Printf("%s: %s - %s (%d words)", result.Url, result.Response.Title, result.Response.ThumbnailUrl, result.Response.WordCount)
+	}
+}
+
+// submitParse submits a job and polls it every second. Every call has its own deadline, the job
+// may take longer than any of them.
+func submitParse(c pb.ParserServiceClient, request *pb.ParserRequest) {
+	call := func(f func(ctx context.Context) (*pb.ParseJob, error)) *pb.ParseJob {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		job, err := f(ctx)
+		if err != nil {
+			log.Fatalf("could not parse: %v", err)
+		}
+		return job
+	}
+	job := call(func(ctx context.Context) (*pb.ParseJob, error) { return c.SubmitParse(ctx, request) })
+	for {
+		log.Printf("Job %s: %s", job.Id, job.State)
+		if w := job.Webhook; w != nil {
+			log.Printf("Callback %s: %s after %d attempts %s", w.Url, w.State, w.Attempts, w.LastError)
+		}
+		switch job.State {
+		case pb.JobState_JOB_STATE_SUCCEEDED:
+			log.Printf("Parsed Title: %s", job.Response.Title)
+			log.Printf("Parsed Thumbnail Image URL: %s", job.Response.ThumbnailUrl)
+			log.Printf("Parsed Content: %s", job.Response.Content)
+			return
+		case pb.JobState_JOB_STATE_FAILED, pb.JobState_JOB_STATE_CANCELLED:
+			log.Printf("%s: %s", codes.Code(job.Code), job.Error)
+			return
+		}
+		time.Sleep(time.Second)
+		job = call(func(ctx context.Context) (*pb.ParseJob, error) {
+			return c.GetParseJob(ctx, &pb.GetParseJobRequest{Id: job.Id})
+		})
+	}
+}
+
+func parseProgressive(ctx context.Context, c pb.ParserServiceClient, request *pb.ParserRequest) {
+	stream, err := c.ParseProgressive(ctx, request)
+	if err != nil {
+		log.Fatalf("could not parse: %v", err)
+	}
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatalf("could not parse: %v", err)
+		}
+		r := update.Response
+		log.Printf("%s: %s - %s - %s (%d words)", update.Stage, r.Title, r.Description, r.ThumbnailUrl, r.WordCount)
+	}
+}
+
+func parseStream(ctx context.Context, c pb.ParserServiceClient, urls []string, request *pb.ParserRequest) {
+	stream, err := c.ParseStream(ctx)
+	if err != nil {
+		log.Fatalf("could not open stream: %v", err)
+	}
+	go func() {
+		for i, inputUrl := range urls {
+			streamRequest := proto.Clone(request).(*pb.ParserRequest)
+			streamRequest.Url = inputUrl
+			if err := stream.Send(&pb.ParseStreamRequest{Id: strconv.Itoa(i), Request: streamRequest}); err != nil {
+				log.Printf("could not send %s: %v", inputUrl, err)
+				break
+			}
+		}
+		stream.CloseSend()
+	}()
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatalf("could not parse stream: %v", err)
+		}
+		result := response.Result
+		if result.Response == nil {
+			log.Printf("#%s %s: %s (%s)", response.Id, result.Url, codes.Code(result.Code), result.Error)
+			continue
+		}
+		log.Printf("#%s %s: %s - %s (%d words)", response.Id, result.Url, result.Response.Title, result.Response.ThumbnailUrl, result.Response.WordCount)
+	}
 }
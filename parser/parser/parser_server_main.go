@@ -1,299 +1,148 @@
 package main
 
 import (
-	"context"
+	"bytes"
 	"flag"
 	"fmt"
-	"github.com/PuerkitoBio/goquery"
+	"io/ioutil"
 	"log"
 	"net"
-	"net/http"
-	"net/url"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"parser/parser/extractor"
 	pb "parser/parser/parserproto"
+	"parser/parser/server"
 )
 
-type parser_server struct{}
-
-func (ps *parser_server) Parse(ctx context.Context, input *pb.ParserRequest) (*pb.ParserResponse, error) {
-	title, imgUrl, content, err := processHTML(input.Url)
-	fmt.Println(title, "-", imgUrl, "-", content, "-", err)
-	return &pb.ParserResponse{Title: title, ThumbnailUrl: imgUrl, Content: content}, err
-}
-
-func processHTML(inputUrl string) (string, string, string, error) {
-	// Check URL validity
-	_, err := url.ParseRequestURI(inputUrl)
-	if err != nil {
-		log.Println(err)
-		return "", "", "", err
-	}
-
-	// HTTP Request
-	response, err := http.Get(inputUrl)
+// loadRules loads the rules file into sites. The registry is left untouched if the file is invalid.
+func loadRules(sites *extractor.Registry, path string) error {
+	rules, err := extractor.LoadRules(path)
 	if err != nil {
-		log.Println(err)
-		return "", "", "", err
+		return err
 	}
-	defer response.Body.Close()
-
-	// Create a goquery document from the HTTP response
-	document, err := goquery.NewDocumentFromReader(response.Body)
-	if err != nil {
-		log.Println("Error loading HTTP response body", err)
-		return "", "", "", err
-	}
-
-	title := getTitle(*document)
-	fmt.Println("Title: " + title)
-	imgUrl := getThumbnailImage(*document)
-	fmt.Println("ImageURL: " + imgUrl)
-	content := getContent(*document)
-	fmt.Println("Content: " + content)
-	return title, imgUrl, content, err
+	sites.Replace(extractor.SitesWithRules(rules)...)
+	log.Printf("Loaded %d rules from %s", len(rules), path)
+	return nil
 }
 
-func getTitle(document goquery.Document) string {
-	// Get <Title> tag
-	title := document.Find("title").Text()
-
-	// Get all titles tagged with <h1>
-	titlesH1 := make([]string, 0)
-	document.Find("h1").Each(func(index int, item *goquery.Selection) {
-		titlesH1 = append(titlesH1, item.Text())
-	})
-
-	// Get all titles tagged with <h2>
-	titlesH2 := make([]string, 0)
-	document.Find("h2").Each(func(index int, item *goquery.Selection) {
-		titlesH2 = append(titlesH2, item.Text())
-	})
-
-	// Get all titles tagged with <h3>
-	titlesH3 := make([]string, 0)
-	document.Find("h3").Each(func(index int, item *goquery.Selection) {
-		titlesH3 = append(titlesH3, item.Text())
-	})
-
-	// Check whether URL contains <title>.
-	// If title is empty, get first <h1> title.
-	if title == "" {
-		if 0 < len(titlesH1) {
-			title = titlesH1[0]
-		}
-	}
-
-	// Check whether URL contains <title> and/or <h1>
-	// If title is empty, get first <h2> title.
-	if title == "" {
-		if 0 < len(titlesH2) {
-			title = titlesH2[0]
-		}
-	}
-
-	// Check whether URL contains <title>, <h1> and/or <h2>
-	// If title is empty, get first <h3> title.
-	if title == "" {
-		if 0 < len(titlesH3) {
-			title = titlesH3[0]
+// reloadRulesOnSighup reloads the rules file every time the process receives SIGHUP.
+func reloadRulesOnSighup(sites *extractor.Registry, path string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		if err := loadRules(sites, path); err != nil {
+			log.Printf("failed to reload rules, keeping the previous ones: %v", err)
 		}
 	}
-
-	// If all title related tags are empty, provide a warning string as title.
-	if title == "" {
-		title = "There is no title-related tags found in the given URL!"
-	}
-
-	return title
 }
 
-func getContent(document goquery.Document) string {
-	var sb strings.Builder
-
-	// Content parser for specific to Medium Blog.
-	document.Find(".section-inner.sectionLayout--insetColumn").Each(func(index int, item *goquery.Selection) {
-		item.Contents().Each(func(i int, ctx *goquery.Selection) {
-			tmp := ctx.Text()
-			if tmp != "" && !strings.Contains(tmp, "BlockedUnblockFollowFollowing") {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", i, tmp)
-			}
-		})
-	})
-
-	if sb.String() == "" {
-		// Content parser for specific to BBC News.
-		document.Find(".story-body__inner").Each(func(index int, item *goquery.Selection) {
-			item.ContentsFiltered("p").Each(func(i int, ctx *goquery.Selection) {
-				tmp := ctx.Text()
-				if tmp != "" && !strings.Contains(tmp, "\n") {
-					sb.WriteString(tmp)
-					sb.WriteString(" ")
-					fmt.Printf("%d: %s\n", i, tmp)
-				}
-			})
-		})
-	}
-
-	if sb.String() == "" {
-		// Content parser for specific to Fox News.
-		document.Find(".article-body").Each(func(index int, item *goquery.Selection) {
-			item.ContentsFiltered("p").Each(func(i int, ctx *goquery.Selection) {
-				tmp := ctx.Text()
-				if tmp != "" && !strings.Contains(tmp, "\n") {
-					sb.WriteString(tmp)
-					sb.WriteString(" ")
-					fmt.Printf("%d: %s\n", i, tmp)
-				}
-			})
-		})
-	}
-
-	if sb.String() == "" {
-		// Content parser for general usage.
-		// Take all texts tagged with <p>
-		document.Find("body p").Each(func(index int, item *goquery.Selection) {
-			tmp := item.Text()
-			if tmp != "" && !strings.Contains(tmp, "\n") {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", index, tmp)
-			}
-		})
-		// Take all texts in the (ordered) list
-		document.Find("body ol").Each(func(index int, item *goquery.Selection) {
-			tmp := item.Text()
-			if tmp != "" {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", index, tmp)
-			}
-		})
-		// Take all texts in the (unordered) list
-		document.Find("body ul").Each(func(index int, item *goquery.Selection) {
-			tmp := item.Text()
-			if tmp != "" {
-				sb.WriteString(tmp)
-				sb.WriteString(" ")
-				fmt.Printf("%d: %s\n", index, tmp)
-			}
-		})
-	}
-
-	if sb.String() == "" {
-		sb.WriteString("Input is either empty webpage or its HTML is not parsable with current state of this code!")
-	}
-
-	return strings.TrimSpace(sb.String())
+// stopOnSignal stops the servers when the process receives SIGINT or SIGTERM: the running RPCs
+// are finished, then the SubmitParse jobs are cancelled and their callbacks delivered. A second
+// signal stops the running RPCs right away. done is closed once the servers are stopped.
+func stopOnSignal(s *grpc.Server, ps *server.ParserServer, done chan<- struct{}) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	log.Printf("Received %s, stopping the server", <-signals)
+	go func() {
+		log.Printf("Received %s, stopping the running requests", <-signals)
+		s.Stop()
+	}()
+	s.GracefulStop()
+	ps.Stop()
+	close(done)
 }
 
-func getThumbnailImage(document goquery.Document) string {
-	// Get the first image from a Medium Blog page.
-	imageUrl := ""
-	document.Find("figure img").Each(func(index int, item *goquery.Selection) {
-		tag := item
-		imageUrl, _ = tag.Attr("src")
-	})
+// headerFlags collects the repeated -header arguments.
+type headerFlags map[string]string
 
-	// Get the image with longest <alt> attribute for a specific newspaper (I do not remember for which one).
-	if imageUrl == "" {
-		prevImgAlt := ""
-		document.Find("body article section").Find("img").Each(func(index int, item *goquery.Selection) {
-			tag := item
-			imageAlt, exist := tag.Attr("alt")
-			if exist {
-				if len(prevImgAlt) < len(imageAlt) {
-					imageUrl, _ = tag.Attr("src")
-					prevImgAlt = imageAlt
-				}
-			} else {
-				imageUrl, _ = tag.Attr("src")
-			}
-		})
-	}
-
-	// Get the image with longest <alt> attribute for a specific newspaper (I do not remember for which one).
-	if imageUrl == "" {
-		prevImgAlt := ""
-		document.Find("body div").Find("img").Each(func(index int, item *goquery.Selection) {
-			tag := item
-			imageAlt, exist := tag.Attr("alt")
-			if exist {
-				if len(prevImgAlt) < len(imageAlt) {
-					imageUrl, _ = tag.Attr("src")
-					prevImgAlt = imageAlt
-				}
-			} else {
-				imageUrl, _ = tag.Attr("src")
-			}
-		})
-	}
-
-	// Get the image with longest <alt> attribute for any web page that are not fit to the previous 3 cases.
-	if imageUrl == "" {
-		prevImgAlt := ""
-		document.Find("img").Each(func(i int, item *goquery.Selection) {
-			tag := item
-			imageAlt, exist := tag.Attr("alt")
-			if exist {
-				if len(prevImgAlt) < len(imageAlt) {
-					imageUrl, _ = tag.Attr("src")
-					prevImgAlt = imageAlt
-				}
-			} else {
-				imageUrl, _ = tag.Attr("src")
-			}
-		})
-	}
-
-	// If page does not have any images, then send a message about it.
-	if imageUrl == "" {
-		imageUrl = "There is no image-related tags found in the given URL!"
-	}
-
-	return imageUrl
-}
-
-// This method is for testing purposes. It is almost the equivalent of the Parse method!
-func (ps *parser_server) ParseTest(ctx context.Context, input *pb.ParserTestRequest) (*pb.ParserResponse, error) {
-	title, imgUrl, content, err := processFileHTML(input.FilePath)
-	return &pb.ParserResponse{Title: title, ThumbnailUrl: imgUrl, Content: content}, err
+func (h headerFlags) String() string {
+	return fmt.Sprint(map[string]string(h))
 }
 
-// This method is for testing purposes. It is almost the equivalent of the processUrl method!
-// Instead of a URL, it takes a file path which contains html page files.
-func processFileHTML(inputFileHtml string) (string, string, string, error) {
-	document, err := goquery.NewDocumentFromReader(strings.NewReader(inputFileHtml))
-
-	title := getTitle(*document)
-	fmt.Println("Title: " + title)
-	imgUrl := getThumbnailImage(*document)
-	fmt.Println("ImageURL: " + imgUrl)
-	content := getContent(*document)
-	fmt.Println("Content: " + content)
-	return title, imgUrl, content, err
+func (h headerFlags) Set(value string) error {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return fmt.Errorf("expected \"Name: value\", got %q", value)
+	}
+	h[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	return nil
 }
 
 func main() {
+	defaults := extractor.DefaultFetcherConfig()
+	headers := headerFlags{}
 	portArg := flag.Int("port", 50051, "An integer argument for port. Default value is 50051")
+	rulesArg := flag.String("rules", "", "A string argument for the path of a JSON/YAML file of per-domain extraction rules. Reloaded on SIGHUP")
+	connectTimeoutArg := flag.Duration("connect-timeout", defaults.ConnectTimeout, "A duration argument for the connection timeout of the page fetches, TLS handshake included. Requests can only lower it")
+	readTimeoutArg := flag.Duration("read-timeout", defaults.ReadTimeout, "A duration argument for the timeout of the response headers and of every read of the page body. Requests can only lower it")
+	timeoutArg := flag.Duration("timeout", defaults.TotalTimeout, "A duration argument for the total timeout of the page fetches. Requests can only lower it")
+	maxBodyBytesArg := flag.Int64("max-body-bytes", defaults.MaxBodyBytes, "An integer argument for the maximum page size in bytes. Requests can only lower it")
+	userAgentArg := flag.String("user-agent", defaults.UserAgent, "A string argument for the User-Agent of the page fetches")
+	acceptLanguageArg := flag.String("accept-language", "", "A string argument for the Accept-Language of the page fetches")
+	maxBatchSizeArg := flag.Int("max-batch-size", server.DefaultMaxBatchSize, "An integer argument for the maximum number of URLs of a BatchParse request, 0 for no limit")
+	batchConcurrencyArg := flag.Int("batch-concurrency", server.DefaultBatchConcurrency, "An integer argument for the number of URLs parsed at the same time by the BatchParse requests")
+	jobWorkersArg := flag.Int("job-workers", server.DefaultJobWorkers, "An integer argument for the number of SubmitParse jobs parsed at the same time")
+	maxQueuedJobsArg := flag.Int("max-queued-jobs", server.DefaultMaxQueuedJobs, "An integer argument for the number of SubmitParse jobs waiting for a worker beyond which new jobs are rejected")
+	jobRetentionArg := flag.Duration("job-retention", server.DefaultJobRetention, "A duration argument for how long finished SubmitParse jobs and their results are kept")
+	webhookSecretFileArg := flag.String("webhook-secret-file", "", "A string argument for the path of a file holding the secret signing the SubmitParse callbacks. Callbacks are rejected without it")
+	webhookAttemptsArg := flag.Int("webhook-attempts", server.DefaultWebhookAttempts, "An integer argument for the maximum number of attempts to deliver a SubmitParse callback")
+	flag.Var(headers, "header", "A \"Name: value\" argument for an extra header of the page fetches. Can be repeated")
 	flag.Parse()
 	port := ":" + strconv.Itoa(*portArg)
 
+	fetcher := extractor.NewFetcher(extractor.FetcherConfig{
+		ConnectTimeout: *connectTimeoutArg,
+		ReadTimeout:    *readTimeoutArg,
+		TotalTimeout:   *timeoutArg,
+		MaxBodyBytes:   *maxBodyBytesArg,
+		UserAgent:      *userAgentArg,
+		AcceptLanguage: *acceptLanguageArg,
+		Headers:        headers,
+	})
+
+	sites := extractor.DefaultRegistry()
+	if *rulesArg != "" {
+		if err := loadRules(sites, *rulesArg); err != nil {
+			log.Fatalf("failed to load rules: %v", err)
+		}
+		go reloadRulesOnSighup(sites, *rulesArg)
+	}
+
+	var webhookSecret []byte
+	if *webhookSecretFileArg != "" {
+		secret, err := ioutil.ReadFile(*webhookSecretFileArg)
+		if err != nil {
+			log.Fatalf("failed to read the webhook secret: %v", err)
+		}
+		if webhookSecret = bytes.TrimSpace(secret); len(webhookSecret) == 0 {
+			log.Fatalf("the webhook secret file %s is empty", *webhookSecretFileArg)
+		}
+	}
+
 	lis, err := net.Listen("tcp", port)
 	log.Printf("Listening the port %s", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	s := grpc.NewServer()
-	pb.RegisterParserServiceServer(s, &parser_server{})
+	ps := server.NewParserServer(extractor.New(extractor.WithSites(sites), extractor.WithFetcher(fetcher)),
+		server.WithBatchLimits(*maxBatchSizeArg, *batchConcurrencyArg),
+		server.WithJobLimits(*jobWorkersArg, *maxQueuedJobsArg, *jobRetentionArg),
+		server.WithWebhooks(webhookSecret, *webhookAttemptsArg, server.DefaultWebhookBackoff))
+	pb.RegisterParserServiceServer(s, ps)
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
+	stopped := make(chan struct{})
+	go stopOnSignal(s, ps, stopped)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+	// Serve returns as soon as the server starts stopping.
+	<-stopped
 }
@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -22,9 +24,240 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// How the content of the page is detected.
+type ContentMode int32
+
+const (
+	// Site specific extractors, JSON-LD and the generic parser of the paragraphs, lists,
+	// <h2>-<h6> headings, blockquotes, code and tables of the body.
+	ContentMode_CONTENT_MODE_DEFAULT ContentMode = 0
+	// Scores the blocks of the page to find the main content and removes the boilerplate
+	// (navigation, footers, cookie banners, comments, ...).
+	ContentMode_CONTENT_MODE_READABILITY ContentMode = 1
+)
+
+var ContentMode_name = map[int32]string{
+	0: "CONTENT_MODE_DEFAULT",
+	1: "CONTENT_MODE_READABILITY",
+}
+
+var ContentMode_value = map[string]int32{
+	"CONTENT_MODE_DEFAULT":     0,
+	"CONTENT_MODE_READABILITY": 1,
+}
+
+func (x ContentMode) String() string {
+	return proto.EnumName(ContentMode_name, int32(x))
+}
+
+func (ContentMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{0}
+}
+
+// Extra formats the content can be returned in, besides the plain text.
+type ContentFormat int32
+
+const (
+	ContentFormat_CONTENT_FORMAT_UNSPECIFIED ContentFormat = 0
+	// Markdown, in content_markdown.
+	ContentFormat_CONTENT_FORMAT_MARKDOWN ContentFormat = 1
+	// Sanitized HTML (allowlisted tags and attributes, absolute links and image URLs), in content_html.
+	ContentFormat_CONTENT_FORMAT_HTML ContentFormat = 2
+)
+
+var ContentFormat_name = map[int32]string{
+	0: "CONTENT_FORMAT_UNSPECIFIED",
+	1: "CONTENT_FORMAT_MARKDOWN",
+	2: "CONTENT_FORMAT_HTML",
+}
+
+var ContentFormat_value = map[string]int32{
+	"CONTENT_FORMAT_UNSPECIFIED": 0,
+	"CONTENT_FORMAT_MARKDOWN":    1,
+	"CONTENT_FORMAT_HTML":        2,
+}
+
+func (x ContentFormat) String() string {
+	return proto.EnumName(ContentFormat_name, int32(x))
+}
+
+func (ContentFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{1}
+}
+
+// The stages of a ParseProgressive call, in order. The metadata and thumbnail stages are
+// only reported for HTML pages.
+type ParseStage int32
+
+const (
+	ParseStage_PARSE_STAGE_UNSPECIFIED ParseStage = 0
+	// The page is fetched: url and content_type are set.
+	ParseStage_PARSE_STAGE_FETCHED ParseStage = 1
+	// The title, description, dates, authors, Open Graph, ... are set.
+	ParseStage_PARSE_STAGE_METADATA ParseStage = 2
+	// The images are ranked and probed: thumbnail_url and images are set.
+	ParseStage_PARSE_STAGE_THUMBNAIL ParseStage = 3
+	// The content is extracted, the response is complete.
+	ParseStage_PARSE_STAGE_CONTENT ParseStage = 4
+)
+
+var ParseStage_name = map[int32]string{
+	0: "PARSE_STAGE_UNSPECIFIED",
+	1: "PARSE_STAGE_FETCHED",
+	2: "PARSE_STAGE_METADATA",
+	3: "PARSE_STAGE_THUMBNAIL",
+	4: "PARSE_STAGE_CONTENT",
+}
+
+var ParseStage_value = map[string]int32{
+	"PARSE_STAGE_UNSPECIFIED": 0,
+	"PARSE_STAGE_FETCHED":     1,
+	"PARSE_STAGE_METADATA":    2,
+	"PARSE_STAGE_THUMBNAIL":   3,
+	"PARSE_STAGE_CONTENT":     4,
+}
+
+func (x ParseStage) String() string {
+	return proto.EnumName(ParseStage_name, int32(x))
+}
+
+func (ParseStage) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{2}
+}
+
+// The states of a ParseJob.
+type JobState int32
+
+const (
+	JobState_JOB_STATE_UNSPECIFIED JobState = 0
+	// Waiting for a free worker.
+	JobState_JOB_STATE_QUEUED  JobState = 1
+	JobState_JOB_STATE_RUNNING JobState = 2
+	// The response is set.
+	JobState_JOB_STATE_SUCCEEDED JobState = 3
+	// The code and error are set.
+	JobState_JOB_STATE_FAILED    JobState = 4
+	JobState_JOB_STATE_CANCELLED JobState = 5
+)
+
+var JobState_name = map[int32]string{
+	0: "JOB_STATE_UNSPECIFIED",
+	1: "JOB_STATE_QUEUED",
+	2: "JOB_STATE_RUNNING",
+	3: "JOB_STATE_SUCCEEDED",
+	4: "JOB_STATE_FAILED",
+	5: "JOB_STATE_CANCELLED",
+}
+
+var JobState_value = map[string]int32{
+	"JOB_STATE_UNSPECIFIED": 0,
+	"JOB_STATE_QUEUED":      1,
+	"JOB_STATE_RUNNING":     2,
+	"JOB_STATE_SUCCEEDED":   3,
+	"JOB_STATE_FAILED":      4,
+	"JOB_STATE_CANCELLED":   5,
+}
+
+func (x JobState) String() string {
+	return proto.EnumName(JobState_name, int32(x))
+}
+
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{3}
+}
+
+// The states of a WebhookDelivery.
+type WebhookState int32
+
+const (
+	WebhookState_WEBHOOK_STATE_UNSPECIFIED WebhookState = 0
+	// Waiting for the job to finish, or for the next attempt.
+	WebhookState_WEBHOOK_STATE_PENDING WebhookState = 1
+	// The callback answered with a 2xx status.
+	WebhookState_WEBHOOK_STATE_DELIVERED WebhookState = 2
+	// All the attempts failed, or the callback answered with a status which is not retried (other
+	// than 2xx, 408, 429 and 5xx: redirects are not followed).
+	WebhookState_WEBHOOK_STATE_FAILED WebhookState = 3
+)
+
+var WebhookState_name = map[int32]string{
+	0: "WEBHOOK_STATE_UNSPECIFIED",
+	1: "WEBHOOK_STATE_PENDING",
+	2: "WEBHOOK_STATE_DELIVERED",
+	3: "WEBHOOK_STATE_FAILED",
+}
+
+var WebhookState_value = map[string]int32{
+	"WEBHOOK_STATE_UNSPECIFIED": 0,
+	"WEBHOOK_STATE_PENDING":     1,
+	"WEBHOOK_STATE_DELIVERED":   2,
+	"WEBHOOK_STATE_FAILED":      3,
+}
+
+func (x WebhookState) String() string {
+	return proto.EnumName(WebhookState_name, int32(x))
+}
+
+func (WebhookState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{4}
+}
+
+type ContentBlock_Type int32
+
+const (
+	ContentBlock_TYPE_UNSPECIFIED ContentBlock_Type = 0
+	ContentBlock_PARAGRAPH        ContentBlock_Type = 1
+	ContentBlock_HEADING          ContentBlock_Type = 2
+	ContentBlock_LIST             ContentBlock_Type = 3
+	ContentBlock_BLOCKQUOTE       ContentBlock_Type = 4
+	ContentBlock_CODE             ContentBlock_Type = 5
+	ContentBlock_TABLE            ContentBlock_Type = 6
+)
+
+var ContentBlock_Type_name = map[int32]string{
+	0: "TYPE_UNSPECIFIED",
+	1: "PARAGRAPH",
+	2: "HEADING",
+	3: "LIST",
+	4: "BLOCKQUOTE",
+	5: "CODE",
+	6: "TABLE",
+}
+
+var ContentBlock_Type_value = map[string]int32{
+	"TYPE_UNSPECIFIED": 0,
+	"PARAGRAPH":        1,
+	"HEADING":          2,
+	"LIST":             3,
+	"BLOCKQUOTE":       4,
+	"CODE":             5,
+	"TABLE":            6,
+}
+
+func (x ContentBlock_Type) String() string {
+	return proto.EnumName(ContentBlock_Type_name, int32(x))
+}
+
+func (ContentBlock_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{22, 0}
+}
+
 // The request message containing the url.
 type ParserRequest struct {
-	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Url            string          `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ContentMode    ContentMode     `protobuf:"varint,2,opt,name=content_mode,json=contentMode,proto3,enum=parser.ContentMode" json:"content_mode,omitempty"`
+	ContentFormats []ContentFormat `protobuf:"varint,3,rep,packed,name=content_formats,json=contentFormats,proto3,enum=parser.ContentFormat" json:"content_formats,omitempty"`
+	// The number of ranked image candidates returned in images, 5 if not set.
+	MaxImages int32 `protobuf:"varint,4,opt,name=max_images,json=maxImages,proto3" json:"max_images,omitempty"`
+	// Fetches the first bytes of the best ranked images to check their type and dimensions,
+	// broken and tiny images are not used as thumbnail.
+	ProbeImages bool `protobuf:"varint,5,opt,name=probe_images,json=probeImages,proto3" json:"probe_images,omitempty"`
+	// Overrides the fetch configuration of the server, within its limits.
+	FetchOptions *FetchOptions `protobuf:"bytes,6,opt,name=fetch_options,json=fetchOptions,proto3" json:"fetch_options,omitempty"`
+	// Only used by SubmitParse: an http or https URL the server POSTs to when the job is
+	// finished. The body is the JSON-encoded ParserResponse when the job succeeded, else a JSON
+	// {"code", "message"} status, signed with the webhook secret of the server.
+	CallbackUrl          string   `protobuf:"bytes,7,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -62,126 +295,2435 @@ func (m *ParserRequest) GetUrl() string {
 	return ""
 }
 
+func (m *ParserRequest) GetContentMode() ContentMode {
+	if m != nil {
+		return m.ContentMode
+	}
+	return ContentMode_CONTENT_MODE_DEFAULT
+}
+
+func (m *ParserRequest) GetContentFormats() []ContentFormat {
+	if m != nil {
+		return m.ContentFormats
+	}
+	return nil
+}
+
+func (m *ParserRequest) GetMaxImages() int32 {
+	if m != nil {
+		return m.MaxImages
+	}
+	return 0
+}
+
+func (m *ParserRequest) GetProbeImages() bool {
+	if m != nil {
+		return m.ProbeImages
+	}
+	return false
+}
+
+func (m *ParserRequest) GetFetchOptions() *FetchOptions {
+	if m != nil {
+		return m.FetchOptions
+	}
+	return nil
+}
+
+func (m *ParserRequest) GetCallbackUrl() string {
+	if m != nil {
+		return m.CallbackUrl
+	}
+	return ""
+}
+
+// How the page is fetched. Unset values keep the server configuration.
+type FetchOptions struct {
+	// Capped at the total timeout of the server.
+	Timeout *duration.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Capped at the maximum body size of the server.
+	MaxBodyBytes   int64  `protobuf:"varint,2,opt,name=max_body_bytes,json=maxBodyBytes,proto3" json:"max_body_bytes,omitempty"`
+	UserAgent      string `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	AcceptLanguage string `protobuf:"bytes,4,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	// Added to the headers of the server, replacing those with the same name.
+	Headers map[string]string `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Capped at the connect timeout of the server.
+	ConnectTimeout *duration.Duration `protobuf:"bytes,6,opt,name=connect_timeout,json=connectTimeout,proto3" json:"connect_timeout,omitempty"`
+	// Capped at the read timeout of the server.
+	ReadTimeout          *duration.Duration `protobuf:"bytes,7,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *FetchOptions) Reset()         { *m = FetchOptions{} }
+func (m *FetchOptions) String() string { return proto.CompactTextString(m) }
+func (*FetchOptions) ProtoMessage()    {}
+func (*FetchOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{1}
+}
+
+func (m *FetchOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchOptions.Unmarshal(m, b)
+}
+func (m *FetchOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchOptions.Marshal(b, m, deterministic)
+}
+func (m *FetchOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchOptions.Merge(m, src)
+}
+func (m *FetchOptions) XXX_Size() int {
+	return xxx_messageInfo_FetchOptions.Size(m)
+}
+func (m *FetchOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchOptions proto.InternalMessageInfo
+
+func (m *FetchOptions) GetTimeout() *duration.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *FetchOptions) GetMaxBodyBytes() int64 {
+	if m != nil {
+		return m.MaxBodyBytes
+	}
+	return 0
+}
+
+func (m *FetchOptions) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+func (m *FetchOptions) GetAcceptLanguage() string {
+	if m != nil {
+		return m.AcceptLanguage
+	}
+	return ""
+}
+
+func (m *FetchOptions) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *FetchOptions) GetConnectTimeout() *duration.Duration {
+	if m != nil {
+		return m.ConnectTimeout
+	}
+	return nil
+}
+
+func (m *FetchOptions) GetReadTimeout() *duration.Duration {
+	if m != nil {
+		return m.ReadTimeout
+	}
+	return nil
+}
+
 // The request message containing the file path.
 type ParserTestRequest struct {
-	FilePath             string   `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	FilePath       string          `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	ContentMode    ContentMode     `protobuf:"varint,2,opt,name=content_mode,json=contentMode,proto3,enum=parser.ContentMode" json:"content_mode,omitempty"`
+	ContentFormats []ContentFormat `protobuf:"varint,3,rep,packed,name=content_formats,json=contentFormats,proto3,enum=parser.ContentFormat" json:"content_formats,omitempty"`
+	// The number of ranked image candidates returned in images, 5 if not set.
+	MaxImages int32 `protobuf:"varint,4,opt,name=max_images,json=maxImages,proto3" json:"max_images,omitempty"`
+	// Fetches the first bytes of the best ranked images to check their type and dimensions,
+	// broken and tiny images are not used as thumbnail.
+	ProbeImages          bool     `protobuf:"varint,5,opt,name=probe_images,json=probeImages,proto3" json:"probe_images,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ParserTestRequest) Reset()         { *m = ParserTestRequest{} }
-func (m *ParserTestRequest) String() string { return proto.CompactTextString(m) }
-func (*ParserTestRequest) ProtoMessage()    {}
-func (*ParserTestRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_128ea0fcf29414eb, []int{1}
+func (m *ParserTestRequest) Reset()         { *m = ParserTestRequest{} }
+func (m *ParserTestRequest) String() string { return proto.CompactTextString(m) }
+func (*ParserTestRequest) ProtoMessage()    {}
+func (*ParserTestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{2}
+}
+
+func (m *ParserTestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParserTestRequest.Unmarshal(m, b)
+}
+func (m *ParserTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParserTestRequest.Marshal(b, m, deterministic)
+}
+func (m *ParserTestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParserTestRequest.Merge(m, src)
+}
+func (m *ParserTestRequest) XXX_Size() int {
+	return xxx_messageInfo_ParserTestRequest.Size(m)
+}
+func (m *ParserTestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParserTestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParserTestRequest proto.InternalMessageInfo
+
+func (m *ParserTestRequest) GetFilePath() string {
+	if m != nil {
+		return m.FilePath
+	}
+	return ""
+}
+
+func (m *ParserTestRequest) GetContentMode() ContentMode {
+	if m != nil {
+		return m.ContentMode
+	}
+	return ContentMode_CONTENT_MODE_DEFAULT
+}
+
+func (m *ParserTestRequest) GetContentFormats() []ContentFormat {
+	if m != nil {
+		return m.ContentFormats
+	}
+	return nil
+}
+
+func (m *ParserTestRequest) GetMaxImages() int32 {
+	if m != nil {
+		return m.MaxImages
+	}
+	return 0
+}
+
+func (m *ParserTestRequest) GetProbeImages() bool {
+	if m != nil {
+		return m.ProbeImages
+	}
+	return false
+}
+
+// The request message containing the urls of a batch, parsed with the same options.
+type BatchParserRequest struct {
+	// At most 100 urls by default, the limit is configured on the server.
+	Urls                 []string        `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	ContentMode          ContentMode     `protobuf:"varint,2,opt,name=content_mode,json=contentMode,proto3,enum=parser.ContentMode" json:"content_mode,omitempty"`
+	ContentFormats       []ContentFormat `protobuf:"varint,3,rep,packed,name=content_formats,json=contentFormats,proto3,enum=parser.ContentFormat" json:"content_formats,omitempty"`
+	MaxImages            int32           `protobuf:"varint,4,opt,name=max_images,json=maxImages,proto3" json:"max_images,omitempty"`
+	ProbeImages          bool            `protobuf:"varint,5,opt,name=probe_images,json=probeImages,proto3" json:"probe_images,omitempty"`
+	FetchOptions         *FetchOptions   `protobuf:"bytes,6,opt,name=fetch_options,json=fetchOptions,proto3" json:"fetch_options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BatchParserRequest) Reset()         { *m = BatchParserRequest{} }
+func (m *BatchParserRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchParserRequest) ProtoMessage()    {}
+func (*BatchParserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{3}
+}
+
+func (m *BatchParserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchParserRequest.Unmarshal(m, b)
+}
+func (m *BatchParserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchParserRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchParserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchParserRequest.Merge(m, src)
+}
+func (m *BatchParserRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchParserRequest.Size(m)
+}
+func (m *BatchParserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchParserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchParserRequest proto.InternalMessageInfo
+
+func (m *BatchParserRequest) GetUrls() []string {
+	if m != nil {
+		return m.Urls
+	}
+	return nil
+}
+
+func (m *BatchParserRequest) GetContentMode() ContentMode {
+	if m != nil {
+		return m.ContentMode
+	}
+	return ContentMode_CONTENT_MODE_DEFAULT
+}
+
+func (m *BatchParserRequest) GetContentFormats() []ContentFormat {
+	if m != nil {
+		return m.ContentFormats
+	}
+	return nil
+}
+
+func (m *BatchParserRequest) GetMaxImages() int32 {
+	if m != nil {
+		return m.MaxImages
+	}
+	return 0
+}
+
+func (m *BatchParserRequest) GetProbeImages() bool {
+	if m != nil {
+		return m.ProbeImages
+	}
+	return false
+}
+
+func (m *BatchParserRequest) GetFetchOptions() *FetchOptions {
+	if m != nil {
+		return m.FetchOptions
+	}
+	return nil
+}
+
+// The results of a batch, in the order of the request urls.
+type BatchParserResponse struct {
+	Results              []*BatchParserResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BatchParserResponse) Reset()         { *m = BatchParserResponse{} }
+func (m *BatchParserResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchParserResponse) ProtoMessage()    {}
+func (*BatchParserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{4}
+}
+
+func (m *BatchParserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchParserResponse.Unmarshal(m, b)
+}
+func (m *BatchParserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchParserResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchParserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchParserResponse.Merge(m, src)
+}
+func (m *BatchParserResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchParserResponse.Size(m)
+}
+func (m *BatchParserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchParserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchParserResponse proto.InternalMessageInfo
+
+func (m *BatchParserResponse) GetResults() []*BatchParserResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchParserResult struct {
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The gRPC status code (google.rpc.Code) Parse would have returned for the url, 0 (OK) on
+	// success, and its error message.
+	Code  int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Only set on success.
+	Response             *ParserResponse `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BatchParserResult) Reset()         { *m = BatchParserResult{} }
+func (m *BatchParserResult) String() string { return proto.CompactTextString(m) }
+func (*BatchParserResult) ProtoMessage()    {}
+func (*BatchParserResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{5}
+}
+
+func (m *BatchParserResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchParserResult.Unmarshal(m, b)
+}
+func (m *BatchParserResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchParserResult.Marshal(b, m, deterministic)
+}
+func (m *BatchParserResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchParserResult.Merge(m, src)
+}
+func (m *BatchParserResult) XXX_Size() int {
+	return xxx_messageInfo_BatchParserResult.Size(m)
+}
+func (m *BatchParserResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchParserResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchParserResult proto.InternalMessageInfo
+
+func (m *BatchParserResult) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *BatchParserResult) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *BatchParserResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *BatchParserResult) GetResponse() *ParserResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// A url to parse in a ParseStream call.
+type ParseStreamRequest struct {
+	// A correlation id chosen by the client, returned with the result.
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Request              *ParserRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ParseStreamRequest) Reset()         { *m = ParseStreamRequest{} }
+func (m *ParseStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*ParseStreamRequest) ProtoMessage()    {}
+func (*ParseStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{6}
+}
+
+func (m *ParseStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseStreamRequest.Unmarshal(m, b)
+}
+func (m *ParseStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseStreamRequest.Marshal(b, m, deterministic)
+}
+func (m *ParseStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseStreamRequest.Merge(m, src)
+}
+func (m *ParseStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_ParseStreamRequest.Size(m)
+}
+func (m *ParseStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseStreamRequest proto.InternalMessageInfo
+
+func (m *ParseStreamRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ParseStreamRequest) GetRequest() *ParserRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+// The result of a ParseStreamRequest.
+type ParseStreamResponse struct {
+	Id                   string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Result               *BatchParserResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ParseStreamResponse) Reset()         { *m = ParseStreamResponse{} }
+func (m *ParseStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*ParseStreamResponse) ProtoMessage()    {}
+func (*ParseStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{7}
+}
+
+func (m *ParseStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseStreamResponse.Unmarshal(m, b)
+}
+func (m *ParseStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseStreamResponse.Marshal(b, m, deterministic)
+}
+func (m *ParseStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseStreamResponse.Merge(m, src)
+}
+func (m *ParseStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_ParseStreamResponse.Size(m)
+}
+func (m *ParseStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseStreamResponse proto.InternalMessageInfo
+
+func (m *ParseStreamResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ParseStreamResponse) GetResult() *BatchParserResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// A partial response of a ParseProgressive call. Every update has all the fields known so far.
+type ParseProgressResponse struct {
+	Stage                ParseStage      `protobuf:"varint,1,opt,name=stage,proto3,enum=parser.ParseStage" json:"stage,omitempty"`
+	Response             *ParserResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ParseProgressResponse) Reset()         { *m = ParseProgressResponse{} }
+func (m *ParseProgressResponse) String() string { return proto.CompactTextString(m) }
+func (*ParseProgressResponse) ProtoMessage()    {}
+func (*ParseProgressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{8}
+}
+
+func (m *ParseProgressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseProgressResponse.Unmarshal(m, b)
+}
+func (m *ParseProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseProgressResponse.Marshal(b, m, deterministic)
+}
+func (m *ParseProgressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseProgressResponse.Merge(m, src)
+}
+func (m *ParseProgressResponse) XXX_Size() int {
+	return xxx_messageInfo_ParseProgressResponse.Size(m)
+}
+func (m *ParseProgressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseProgressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseProgressResponse proto.InternalMessageInfo
+
+func (m *ParseProgressResponse) GetStage() ParseStage {
+	if m != nil {
+		return m.Stage
+	}
+	return ParseStage_PARSE_STAGE_UNSPECIFIED
+}
+
+func (m *ParseProgressResponse) GetResponse() *ParserResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// A url parsed in the background, created by SubmitParse. Finished jobs are kept for the
+// retention period of the server, then GetParseJob fails with NOT_FOUND.
+type ParseJob struct {
+	Id          string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url         string               `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	State       JobState             `protobuf:"varint,3,opt,name=state,proto3,enum=parser.JobState" json:"state,omitempty"`
+	CreatedTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_time,json=createdTime,proto3" json:"created_time,omitempty"`
+	// Unset while the job is queued.
+	StartedTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=started_time,json=startedTime,proto3" json:"started_time,omitempty"`
+	// Unset until the job succeeded, failed or was cancelled.
+	FinishedTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=finished_time,json=finishedTime,proto3" json:"finished_time,omitempty"`
+	// The gRPC status code (google.rpc.Code) Parse would have returned for the url, once the job
+	// is finished.
+	Code int32 `protobuf:"varint,7,opt,name=code,proto3" json:"code,omitempty"`
+	// The status message, only set when the job failed or was cancelled.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// Only set by GetParseJob when the job succeeded.
+	Response *ParserResponse `protobuf:"bytes,9,opt,name=response,proto3" json:"response,omitempty"`
+	// The delivery of the callback, when the job was submitted with a callback_url.
+	Webhook              *WebhookDelivery `protobuf:"bytes,10,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ParseJob) Reset()         { *m = ParseJob{} }
+func (m *ParseJob) String() string { return proto.CompactTextString(m) }
+func (*ParseJob) ProtoMessage()    {}
+func (*ParseJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{9}
+}
+
+func (m *ParseJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseJob.Unmarshal(m, b)
+}
+func (m *ParseJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseJob.Marshal(b, m, deterministic)
+}
+func (m *ParseJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseJob.Merge(m, src)
+}
+func (m *ParseJob) XXX_Size() int {
+	return xxx_messageInfo_ParseJob.Size(m)
+}
+func (m *ParseJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseJob proto.InternalMessageInfo
+
+func (m *ParseJob) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ParseJob) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *ParseJob) GetState() JobState {
+	if m != nil {
+		return m.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (m *ParseJob) GetCreatedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedTime
+	}
+	return nil
+}
+
+func (m *ParseJob) GetStartedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedTime
+	}
+	return nil
+}
+
+func (m *ParseJob) GetFinishedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedTime
+	}
+	return nil
+}
+
+func (m *ParseJob) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ParseJob) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ParseJob) GetResponse() *ParserResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ParseJob) GetWebhook() *WebhookDelivery {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+// The delivery of the callback of a ParseJob.
+type WebhookDelivery struct {
+	Url             string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	State           WebhookState         `protobuf:"varint,2,opt,name=state,proto3,enum=parser.WebhookState" json:"state,omitempty"`
+	Attempts        int32                `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastAttemptTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=last_attempt_time,json=lastAttemptTime,proto3" json:"last_attempt_time,omitempty"`
+	// The HTTP status of the last attempt, 0 if there was no response.
+	LastStatusCode int32 `protobuf:"varint,5,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	// Why the last attempt failed.
+	LastError            string   `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{10}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetState() WebhookState {
+	if m != nil {
+		return m.State
+	}
+	return WebhookState_WEBHOOK_STATE_UNSPECIFIED
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastAttemptTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAttemptTime
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetLastStatusCode() int32 {
+	if m != nil {
+		return m.LastStatusCode
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type GetParseJobRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetParseJobRequest) Reset()         { *m = GetParseJobRequest{} }
+func (m *GetParseJobRequest) String() string { return proto.CompactTextString(m) }
+func (*GetParseJobRequest) ProtoMessage()    {}
+func (*GetParseJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{11}
+}
+
+func (m *GetParseJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetParseJobRequest.Unmarshal(m, b)
+}
+func (m *GetParseJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetParseJobRequest.Marshal(b, m, deterministic)
+}
+func (m *GetParseJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetParseJobRequest.Merge(m, src)
+}
+func (m *GetParseJobRequest) XXX_Size() int {
+	return xxx_messageInfo_GetParseJobRequest.Size(m)
+}
+func (m *GetParseJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetParseJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetParseJobRequest proto.InternalMessageInfo
+
+func (m *GetParseJobRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListParseJobsRequest struct {
+	// Only lists the jobs in this state, if set.
+	State JobState `protobuf:"varint,1,opt,name=state,proto3,enum=parser.JobState" json:"state,omitempty"`
+	// The maximum number of jobs returned, 100 if unset.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous page, if any.
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListParseJobsRequest) Reset()         { *m = ListParseJobsRequest{} }
+func (m *ListParseJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListParseJobsRequest) ProtoMessage()    {}
+func (*ListParseJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{12}
+}
+
+func (m *ListParseJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListParseJobsRequest.Unmarshal(m, b)
+}
+func (m *ListParseJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListParseJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListParseJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListParseJobsRequest.Merge(m, src)
+}
+func (m *ListParseJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListParseJobsRequest.Size(m)
+}
+func (m *ListParseJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListParseJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListParseJobsRequest proto.InternalMessageInfo
+
+func (m *ListParseJobsRequest) GetState() JobState {
+	if m != nil {
+		return m.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (m *ListParseJobsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListParseJobsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListParseJobsResponse struct {
+	Jobs []*ParseJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Set when there are more jobs, to pass as page_token.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListParseJobsResponse) Reset()         { *m = ListParseJobsResponse{} }
+func (m *ListParseJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListParseJobsResponse) ProtoMessage()    {}
+func (*ListParseJobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{13}
+}
+
+func (m *ListParseJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListParseJobsResponse.Unmarshal(m, b)
+}
+func (m *ListParseJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListParseJobsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListParseJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListParseJobsResponse.Merge(m, src)
+}
+func (m *ListParseJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListParseJobsResponse.Size(m)
+}
+func (m *ListParseJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListParseJobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListParseJobsResponse proto.InternalMessageInfo
+
+func (m *ListParseJobsResponse) GetJobs() []*ParseJob {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *ListParseJobsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CancelParseJobRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelParseJobRequest) Reset()         { *m = CancelParseJobRequest{} }
+func (m *CancelParseJobRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelParseJobRequest) ProtoMessage()    {}
+func (*CancelParseJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{14}
+}
+
+func (m *CancelParseJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelParseJobRequest.Unmarshal(m, b)
+}
+func (m *CancelParseJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelParseJobRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelParseJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelParseJobRequest.Merge(m, src)
+}
+func (m *CancelParseJobRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelParseJobRequest.Size(m)
+}
+func (m *CancelParseJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelParseJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelParseJobRequest proto.InternalMessageInfo
+
+func (m *CancelParseJobRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// The request message containing the feed url.
+type FeedRequest struct {
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The number of entries returned, all of them if not set.
+	MaxEntries int32 `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// Parses the link of every returned entry (the first 25 at most) like Parse, into the
+	// article of the entry. The options below are used for these articles.
+	ParseEntries   bool            `protobuf:"varint,3,opt,name=parse_entries,json=parseEntries,proto3" json:"parse_entries,omitempty"`
+	ContentMode    ContentMode     `protobuf:"varint,4,opt,name=content_mode,json=contentMode,proto3,enum=parser.ContentMode" json:"content_mode,omitempty"`
+	ContentFormats []ContentFormat `protobuf:"varint,5,rep,packed,name=content_formats,json=contentFormats,proto3,enum=parser.ContentFormat" json:"content_formats,omitempty"`
+	MaxImages      int32           `protobuf:"varint,6,opt,name=max_images,json=maxImages,proto3" json:"max_images,omitempty"`
+	ProbeImages    bool            `protobuf:"varint,7,opt,name=probe_images,json=probeImages,proto3" json:"probe_images,omitempty"`
+	// Overrides the fetch configuration of the server for the feed and the articles.
+	FetchOptions         *FetchOptions `protobuf:"bytes,8,opt,name=fetch_options,json=fetchOptions,proto3" json:"fetch_options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *FeedRequest) Reset()         { *m = FeedRequest{} }
+func (m *FeedRequest) String() string { return proto.CompactTextString(m) }
+func (*FeedRequest) ProtoMessage()    {}
+func (*FeedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{15}
+}
+
+func (m *FeedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeedRequest.Unmarshal(m, b)
+}
+func (m *FeedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeedRequest.Marshal(b, m, deterministic)
+}
+func (m *FeedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeedRequest.Merge(m, src)
+}
+func (m *FeedRequest) XXX_Size() int {
+	return xxx_messageInfo_FeedRequest.Size(m)
+}
+func (m *FeedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeedRequest proto.InternalMessageInfo
+
+func (m *FeedRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *FeedRequest) GetMaxEntries() int32 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+func (m *FeedRequest) GetParseEntries() bool {
+	if m != nil {
+		return m.ParseEntries
+	}
+	return false
+}
+
+func (m *FeedRequest) GetContentMode() ContentMode {
+	if m != nil {
+		return m.ContentMode
+	}
+	return ContentMode_CONTENT_MODE_DEFAULT
+}
+
+func (m *FeedRequest) GetContentFormats() []ContentFormat {
+	if m != nil {
+		return m.ContentFormats
+	}
+	return nil
+}
+
+func (m *FeedRequest) GetMaxImages() int32 {
+	if m != nil {
+		return m.MaxImages
+	}
+	return 0
+}
+
+func (m *FeedRequest) GetProbeImages() bool {
+	if m != nil {
+		return m.ProbeImages
+	}
+	return false
+}
+
+func (m *FeedRequest) GetFetchOptions() *FetchOptions {
+	if m != nil {
+		return m.FetchOptions
+	}
+	return nil
+}
+
+// The metadata and entries of a feed. Values that are not found in the feed are left empty.
+type FeedResponse struct {
+	// The URL the feed was finally fetched from, after redirects.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// "rss" (RSS 2.0 and 1.0), "atom" or "json".
+	Format      string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Title       string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// The address of the site of the feed.
+	Link     string               `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"`
+	Language string               `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	ImageUrl string               `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Updated  *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated,proto3" json:"updated,omitempty"`
+	// In the order of the feed.
+	Entries              []*FeedEntry `protobuf:"bytes,9,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *FeedResponse) Reset()         { *m = FeedResponse{} }
+func (m *FeedResponse) String() string { return proto.CompactTextString(m) }
+func (*FeedResponse) ProtoMessage()    {}
+func (*FeedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{16}
+}
+
+func (m *FeedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeedResponse.Unmarshal(m, b)
+}
+func (m *FeedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeedResponse.Marshal(b, m, deterministic)
+}
+func (m *FeedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeedResponse.Merge(m, src)
+}
+func (m *FeedResponse) XXX_Size() int {
+	return xxx_messageInfo_FeedResponse.Size(m)
+}
+func (m *FeedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeedResponse proto.InternalMessageInfo
+
+func (m *FeedResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetImageUrl() string {
+	if m != nil {
+		return m.ImageUrl
+	}
+	return ""
+}
+
+func (m *FeedResponse) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+func (m *FeedResponse) GetEntries() []*FeedEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type FeedEntry struct {
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Link  string `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
+	// Not set if the entry does not provide them.
+	Published *timestamp.Timestamp `protobuf:"bytes,4,opt,name=published,proto3" json:"published,omitempty"`
+	Updated   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	// The plain text of the summary, or of the content if there is none, cut to 1000
+	// characters at a word boundary.
+	Summary string `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`
+	// The enclosure or media image of the entry, or the first image of its content.
+	ImageUrl string   `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Authors  []string `protobuf:"bytes,8,rep,name=authors,proto3" json:"authors,omitempty"`
+	// The parsed link, only set with parse_entries. article_error is set instead if parsing failed.
+	Article              *ParserResponse `protobuf:"bytes,9,opt,name=article,proto3" json:"article,omitempty"`
+	ArticleError         string          `protobuf:"bytes,10,opt,name=article_error,json=articleError,proto3" json:"article_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FeedEntry) Reset()         { *m = FeedEntry{} }
+func (m *FeedEntry) String() string { return proto.CompactTextString(m) }
+func (*FeedEntry) ProtoMessage()    {}
+func (*FeedEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{17}
+}
+
+func (m *FeedEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeedEntry.Unmarshal(m, b)
+}
+func (m *FeedEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeedEntry.Marshal(b, m, deterministic)
+}
+func (m *FeedEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeedEntry.Merge(m, src)
+}
+func (m *FeedEntry) XXX_Size() int {
+	return xxx_messageInfo_FeedEntry.Size(m)
+}
+func (m *FeedEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeedEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeedEntry proto.InternalMessageInfo
+
+func (m *FeedEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *FeedEntry) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *FeedEntry) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+func (m *FeedEntry) GetPublished() *timestamp.Timestamp {
+	if m != nil {
+		return m.Published
+	}
+	return nil
+}
+
+func (m *FeedEntry) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+func (m *FeedEntry) GetSummary() string {
+	if m != nil {
+		return m.Summary
+	}
+	return ""
+}
+
+func (m *FeedEntry) GetImageUrl() string {
+	if m != nil {
+		return m.ImageUrl
+	}
+	return ""
+}
+
+func (m *FeedEntry) GetAuthors() []string {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *FeedEntry) GetArticle() *ParserResponse {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+func (m *FeedEntry) GetArticleError() string {
+	if m != nil {
+		return m.ArticleError
+	}
+	return ""
+}
+
+// The response message containing the url's title, body and links of thumbnails,
+// as well as the page metadata.
+// Values that are not found in the page are left empty; the has_* flags tell whether
+// title, thumbnail_url and content were found.
+type ParserResponse struct {
+	Title        string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	ThumbnailUrl string   `protobuf:"bytes,2,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	Content      string   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	HasTitle     bool     `protobuf:"varint,15,opt,name=has_title,json=hasTitle,proto3" json:"has_title,omitempty"`
+	HasThumbnail bool     `protobuf:"varint,16,opt,name=has_thumbnail,json=hasThumbnail,proto3" json:"has_thumbnail,omitempty"`
+	HasContent   bool     `protobuf:"varint,17,opt,name=has_content,json=hasContent,proto3" json:"has_content,omitempty"`
+	Description  string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CanonicalUrl string   `protobuf:"bytes,5,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`
+	SiteName     string   `protobuf:"bytes,6,opt,name=site_name,json=siteName,proto3" json:"site_name,omitempty"`
+	Authors      []string `protobuf:"bytes,7,rep,name=authors,proto3" json:"authors,omitempty"`
+	// Not set if the page does not provide them.
+	PublishedTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=published_time,json=publishedTime,proto3" json:"published_time,omitempty"`
+	ModifiedTime  *timestamp.Timestamp `protobuf:"bytes,9,opt,name=modified_time,json=modifiedTime,proto3" json:"modified_time,omitempty"`
+	Language      string               `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	Keywords      []string             `protobuf:"bytes,11,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	FaviconUrl    string               `protobuf:"bytes,12,opt,name=favicon_url,json=faviconUrl,proto3" json:"favicon_url,omitempty"`
+	WordCount     int32                `protobuf:"varint,13,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	// The URL the page was finally fetched from, after redirects.
+	FinalUrl string `protobuf:"bytes,14,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	// Not set if the page has no such tags.
+	OpenGraph   *OpenGraph   `protobuf:"bytes,18,opt,name=open_graph,json=openGraph,proto3" json:"open_graph,omitempty"`
+	TwitterCard *TwitterCard `protobuf:"bytes,19,opt,name=twitter_card,json=twitterCard,proto3" json:"twitter_card,omitempty"`
+	// The schema.org article found in the JSON-LD blocks of the page, not set if there is none.
+	StructuredData *StructuredData `protobuf:"bytes,20,opt,name=structured_data,json=structuredData,proto3" json:"structured_data,omitempty"`
+	// The raw content of every JSON-LD block of the page.
+	JsonLd []string `protobuf:"bytes,21,rep,name=json_ld,json=jsonLd,proto3" json:"json_ld,omitempty"`
+	// The name of the site specific extractor which handled the page ("medium", "bbc", ...),
+	// "readability" if the content was detected by CONTENT_MODE_READABILITY, "text", "image" or
+	// "pdf" for plain text, image and PDF pages, or "generic".
+	Extractor string `protobuf:"bytes,22,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	// The structural elements of the content, in document order. content is their flat text.
+	Blocks []*ContentBlock `protobuf:"bytes,23,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// The content as Markdown and as sanitized HTML, only set if requested in content_formats.
+	ContentMarkdown string `protobuf:"bytes,24,opt,name=content_markdown,json=contentMarkdown,proto3" json:"content_markdown,omitempty"`
+	ContentHtml     string `protobuf:"bytes,25,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
+	// The best ranked image candidates, best first. thumbnail_url is the first one, unless
+	// all of them look like logos, tracking pixels, ...
+	Images []*ImageCandidate `protobuf:"bytes,26,rep,name=images,proto3" json:"images,omitempty"`
+	// The type and dimensions of the thumbnail, only set if probe_images is set.
+	ThumbnailProbe *ImageProbe `protobuf:"bytes,27,opt,name=thumbnail_probe,json=thumbnailProbe,proto3" json:"thumbnail_probe,omitempty"`
+	// The detected charset of the page, e.g. "windows-1254". The page is transcoded to UTF-8
+	// before it is parsed.
+	Charset string `protobuf:"bytes,28,opt,name=charset,proto3" json:"charset,omitempty"`
+	// The media type of the page, e.g. "text/html". HTML pages are parsed, plain text is returned
+	// as the content, images as the thumbnail and the text of PDF documents as the content.
+	ContentType string `protobuf:"bytes,29,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The number of pages of a PDF document, 0 for other pages.
+	PageCount int32 `protobuf:"varint,30,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	// The AMP version of the page (<link rel="amphtml">).
+	AmpUrl string `protobuf:"bytes,31,opt,name=amp_url,json=ampUrl,proto3" json:"amp_url,omitempty"`
+	// The RSS, Atom and JSON feeds and the oEmbed endpoints advertised by the
+	// <link rel="alternate"> elements of the page, in page order.
+	Feeds                []*AlternateLink `protobuf:"bytes,32,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	OembedEndpoints      []*AlternateLink `protobuf:"bytes,33,rep,name=oembed_endpoints,json=oembedEndpoints,proto3" json:"oembed_endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ParserResponse) Reset()         { *m = ParserResponse{} }
+func (m *ParserResponse) String() string { return proto.CompactTextString(m) }
+func (*ParserResponse) ProtoMessage()    {}
+func (*ParserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{18}
+}
+
+func (m *ParserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParserResponse.Unmarshal(m, b)
+}
+func (m *ParserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParserResponse.Marshal(b, m, deterministic)
+}
+func (m *ParserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParserResponse.Merge(m, src)
+}
+func (m *ParserResponse) XXX_Size() int {
+	return xxx_messageInfo_ParserResponse.Size(m)
+}
+func (m *ParserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParserResponse proto.InternalMessageInfo
+
+func (m *ParserResponse) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetThumbnailUrl() string {
+	if m != nil {
+		return m.ThumbnailUrl
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetHasTitle() bool {
+	if m != nil {
+		return m.HasTitle
+	}
+	return false
+}
+
+func (m *ParserResponse) GetHasThumbnail() bool {
+	if m != nil {
+		return m.HasThumbnail
+	}
+	return false
+}
+
+func (m *ParserResponse) GetHasContent() bool {
+	if m != nil {
+		return m.HasContent
+	}
+	return false
+}
+
+func (m *ParserResponse) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetCanonicalUrl() string {
+	if m != nil {
+		return m.CanonicalUrl
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetSiteName() string {
+	if m != nil {
+		return m.SiteName
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetAuthors() []string {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetPublishedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishedTime
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetModifiedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ModifiedTime
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetKeywords() []string {
+	if m != nil {
+		return m.Keywords
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetFaviconUrl() string {
+	if m != nil {
+		return m.FaviconUrl
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetWordCount() int32 {
+	if m != nil {
+		return m.WordCount
+	}
+	return 0
+}
+
+func (m *ParserResponse) GetFinalUrl() string {
+	if m != nil {
+		return m.FinalUrl
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetOpenGraph() *OpenGraph {
+	if m != nil {
+		return m.OpenGraph
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetTwitterCard() *TwitterCard {
+	if m != nil {
+		return m.TwitterCard
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetStructuredData() *StructuredData {
+	if m != nil {
+		return m.StructuredData
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetJsonLd() []string {
+	if m != nil {
+		return m.JsonLd
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetExtractor() string {
+	if m != nil {
+		return m.Extractor
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetBlocks() []*ContentBlock {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetContentMarkdown() string {
+	if m != nil {
+		return m.ContentMarkdown
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetContentHtml() string {
+	if m != nil {
+		return m.ContentHtml
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetImages() []*ImageCandidate {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetThumbnailProbe() *ImageProbe {
+	if m != nil {
+		return m.ThumbnailProbe
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetCharset() string {
+	if m != nil {
+		return m.Charset
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetPageCount() int32 {
+	if m != nil {
+		return m.PageCount
+	}
+	return 0
+}
+
+func (m *ParserResponse) GetAmpUrl() string {
+	if m != nil {
+		return m.AmpUrl
+	}
+	return ""
+}
+
+func (m *ParserResponse) GetFeeds() []*AlternateLink {
+	if m != nil {
+		return m.Feeds
+	}
+	return nil
+}
+
+func (m *ParserResponse) GetOembedEndpoints() []*AlternateLink {
+	if m != nil {
+		return m.OembedEndpoints
+	}
+	return nil
+}
+
+// Another representation of a page advertised in its <head>: a feed or an oEmbed endpoint.
+type AlternateLink struct {
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The media type of the link, e.g. "application/rss+xml" or "application/json+oembed".
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlternateLink) Reset()         { *m = AlternateLink{} }
+func (m *AlternateLink) String() string { return proto.CompactTextString(m) }
+func (*AlternateLink) ProtoMessage()    {}
+func (*AlternateLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{19}
+}
+
+func (m *AlternateLink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlternateLink.Unmarshal(m, b)
+}
+func (m *AlternateLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlternateLink.Marshal(b, m, deterministic)
+}
+func (m *AlternateLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlternateLink.Merge(m, src)
+}
+func (m *AlternateLink) XXX_Size() int {
+	return xxx_messageInfo_AlternateLink.Size(m)
+}
+func (m *AlternateLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlternateLink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlternateLink proto.InternalMessageInfo
+
+func (m *AlternateLink) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *AlternateLink) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *AlternateLink) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+// An image of the page ranked as a possible thumbnail.
+type ImageCandidate struct {
+	Url   string  `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// What the score is made of, e.g. "og:image", "large (800x600)" or "file name looks like a logo".
+	Reasons []string `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	// From the width/height attributes of the <img>, 0 if unknown.
+	Width  int32  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Alt    string `protobuf:"bytes,6,opt,name=alt,proto3" json:"alt,omitempty"`
+	// Only set if probe_images is set. rejected tells whether the probe found the image broken or too small.
+	Probe                *ImageProbe `protobuf:"bytes,7,opt,name=probe,proto3" json:"probe,omitempty"`
+	Rejected             bool        `protobuf:"varint,8,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ImageCandidate) Reset()         { *m = ImageCandidate{} }
+func (m *ImageCandidate) String() string { return proto.CompactTextString(m) }
+func (*ImageCandidate) ProtoMessage()    {}
+func (*ImageCandidate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{20}
+}
+
+func (m *ImageCandidate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageCandidate.Unmarshal(m, b)
+}
+func (m *ImageCandidate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageCandidate.Marshal(b, m, deterministic)
+}
+func (m *ImageCandidate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageCandidate.Merge(m, src)
+}
+func (m *ImageCandidate) XXX_Size() int {
+	return xxx_messageInfo_ImageCandidate.Size(m)
+}
+func (m *ImageCandidate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageCandidate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageCandidate proto.InternalMessageInfo
+
+func (m *ImageCandidate) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *ImageCandidate) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *ImageCandidate) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+func (m *ImageCandidate) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ImageCandidate) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ImageCandidate) GetAlt() string {
+	if m != nil {
+		return m.Alt
+	}
+	return ""
+}
+
+func (m *ImageCandidate) GetProbe() *ImageProbe {
+	if m != nil {
+		return m.Probe
+	}
+	return nil
+}
+
+func (m *ImageCandidate) GetRejected() bool {
+	if m != nil {
+		return m.Rejected
+	}
+	return false
+}
+
+// What probing an image found out about it.
+type ImageProbe struct {
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// 0 if the format can not be decoded (e.g. SVG or WebP).
+	Width                int32    `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImageProbe) Reset()         { *m = ImageProbe{} }
+func (m *ImageProbe) String() string { return proto.CompactTextString(m) }
+func (*ImageProbe) ProtoMessage()    {}
+func (*ImageProbe) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{21}
+}
+
+func (m *ImageProbe) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageProbe.Unmarshal(m, b)
+}
+func (m *ImageProbe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageProbe.Marshal(b, m, deterministic)
+}
+func (m *ImageProbe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageProbe.Merge(m, src)
+}
+func (m *ImageProbe) XXX_Size() int {
+	return xxx_messageInfo_ImageProbe.Size(m)
+}
+func (m *ImageProbe) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageProbe.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageProbe proto.InternalMessageInfo
+
+func (m *ImageProbe) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *ImageProbe) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ImageProbe) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// A structural element of the content. Only the fields of its type are set.
+type ContentBlock struct {
+	Type ContentBlock_Type `protobuf:"varint,1,opt,name=type,proto3,enum=parser.ContentBlock_Type" json:"type,omitempty"`
+	// The text of paragraphs, headings, blockquotes and code. The whitespace of code is kept.
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// The level of headings, 1 to 6.
+	Level int32 `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	// Whether a list is ordered, and the texts of its items.
+	Ordered bool     `protobuf:"varint,4,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	Items   []string `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	// The rows of a table, header row included.
+	Rows                 []*TableRow `protobuf:"bytes,6,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ContentBlock) Reset()         { *m = ContentBlock{} }
+func (m *ContentBlock) String() string { return proto.CompactTextString(m) }
+func (*ContentBlock) ProtoMessage()    {}
+func (*ContentBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{22}
+}
+
+func (m *ContentBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentBlock.Unmarshal(m, b)
+}
+func (m *ContentBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentBlock.Marshal(b, m, deterministic)
+}
+func (m *ContentBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentBlock.Merge(m, src)
+}
+func (m *ContentBlock) XXX_Size() int {
+	return xxx_messageInfo_ContentBlock.Size(m)
+}
+func (m *ContentBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentBlock proto.InternalMessageInfo
+
+func (m *ContentBlock) GetType() ContentBlock_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ContentBlock_TYPE_UNSPECIFIED
+}
+
+func (m *ContentBlock) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *ContentBlock) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *ContentBlock) GetOrdered() bool {
+	if m != nil {
+		return m.Ordered
+	}
+	return false
+}
+
+func (m *ContentBlock) GetItems() []string {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ContentBlock) GetRows() []*TableRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+// A row of a table block.
+type TableRow struct {
+	Cells                []string `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TableRow) Reset()         { *m = TableRow{} }
+func (m *TableRow) String() string { return proto.CompactTextString(m) }
+func (*TableRow) ProtoMessage()    {}
+func (*TableRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{23}
+}
+
+func (m *TableRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TableRow.Unmarshal(m, b)
+}
+func (m *TableRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TableRow.Marshal(b, m, deterministic)
+}
+func (m *TableRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableRow.Merge(m, src)
+}
+func (m *TableRow) XXX_Size() int {
+	return xxx_messageInfo_TableRow.Size(m)
+}
+func (m *TableRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableRow proto.InternalMessageInfo
+
+func (m *TableRow) GetCells() []string {
+	if m != nil {
+		return m.Cells
+	}
+	return nil
+}
+
+// The Open Graph (og:*) properties of a page.
+type OpenGraph struct {
+	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Type        string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Url         string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	SiteName    string `protobuf:"bytes,5,opt,name=site_name,json=siteName,proto3" json:"site_name,omitempty"`
+	Locale      string `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Every og:image of the page, the first one is the main image.
+	Images               []string `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
+	Video                string   `protobuf:"bytes,8,opt,name=video,proto3" json:"video,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenGraph) Reset()         { *m = OpenGraph{} }
+func (m *OpenGraph) String() string { return proto.CompactTextString(m) }
+func (*OpenGraph) ProtoMessage()    {}
+func (*OpenGraph) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{24}
+}
+
+func (m *OpenGraph) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenGraph.Unmarshal(m, b)
+}
+func (m *OpenGraph) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenGraph.Marshal(b, m, deterministic)
+}
+func (m *OpenGraph) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenGraph.Merge(m, src)
+}
+func (m *OpenGraph) XXX_Size() int {
+	return xxx_messageInfo_OpenGraph.Size(m)
+}
+func (m *OpenGraph) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenGraph.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenGraph proto.InternalMessageInfo
+
+func (m *OpenGraph) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *OpenGraph) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *OpenGraph) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *OpenGraph) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *OpenGraph) GetSiteName() string {
+	if m != nil {
+		return m.SiteName
+	}
+	return ""
+}
+
+func (m *OpenGraph) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *OpenGraph) GetImages() []string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+func (m *OpenGraph) GetVideo() string {
+	if m != nil {
+		return m.Video
+	}
+	return ""
+}
+
+// The Twitter Card (twitter:*) properties of a page.
+type TwitterCard struct {
+	Card                 string   `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Site                 string   `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	Creator              string   `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Title                string   `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description          string   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Image                string   `protobuf:"bytes,6,opt,name=image,proto3" json:"image,omitempty"`
+	ImageAlt             string   `protobuf:"bytes,7,opt,name=image_alt,json=imageAlt,proto3" json:"image_alt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TwitterCard) Reset()         { *m = TwitterCard{} }
+func (m *TwitterCard) String() string { return proto.CompactTextString(m) }
+func (*TwitterCard) ProtoMessage()    {}
+func (*TwitterCard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{25}
+}
+
+func (m *TwitterCard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TwitterCard.Unmarshal(m, b)
+}
+func (m *TwitterCard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TwitterCard.Marshal(b, m, deterministic)
+}
+func (m *TwitterCard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TwitterCard.Merge(m, src)
+}
+func (m *TwitterCard) XXX_Size() int {
+	return xxx_messageInfo_TwitterCard.Size(m)
+}
+func (m *TwitterCard) XXX_DiscardUnknown() {
+	xxx_messageInfo_TwitterCard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TwitterCard proto.InternalMessageInfo
+
+func (m *TwitterCard) GetCard() string {
+	if m != nil {
+		return m.Card
+	}
+	return ""
+}
+
+func (m *TwitterCard) GetSite() string {
+	if m != nil {
+		return m.Site
+	}
+	return ""
+}
+
+func (m *TwitterCard) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *TwitterCard) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *TwitterCard) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *TwitterCard) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *TwitterCard) GetImageAlt() string {
+	if m != nil {
+		return m.ImageAlt
+	}
+	return ""
+}
+
+// The schema.org article (NewsArticle, BlogPosting, ...) of a page.
+type StructuredData struct {
+	Type                 string               `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Headline             string               `protobuf:"bytes,2,opt,name=headline,proto3" json:"headline,omitempty"`
+	Description          string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Authors              []string             `protobuf:"bytes,4,rep,name=authors,proto3" json:"authors,omitempty"`
+	DatePublished        *timestamp.Timestamp `protobuf:"bytes,5,opt,name=date_published,json=datePublished,proto3" json:"date_published,omitempty"`
+	DateModified         *timestamp.Timestamp `protobuf:"bytes,6,opt,name=date_modified,json=dateModified,proto3" json:"date_modified,omitempty"`
+	Images               []string             `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
+	ArticleBody          string               `protobuf:"bytes,8,opt,name=article_body,json=articleBody,proto3" json:"article_body,omitempty"`
+	Publisher            string               `protobuf:"bytes,9,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	Keywords             []string             `protobuf:"bytes,10,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	Url                  string               `protobuf:"bytes,11,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ParserTestRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ParserTestRequest.Unmarshal(m, b)
+func (m *StructuredData) Reset()         { *m = StructuredData{} }
+func (m *StructuredData) String() string { return proto.CompactTextString(m) }
+func (*StructuredData) ProtoMessage()    {}
+func (*StructuredData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_128ea0fcf29414eb, []int{26}
 }
-func (m *ParserTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ParserTestRequest.Marshal(b, m, deterministic)
+
+func (m *StructuredData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StructuredData.Unmarshal(m, b)
 }
-func (m *ParserTestRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ParserTestRequest.Merge(m, src)
+func (m *StructuredData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StructuredData.Marshal(b, m, deterministic)
 }
-func (m *ParserTestRequest) XXX_Size() int {
-	return xxx_messageInfo_ParserTestRequest.Size(m)
+func (m *StructuredData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StructuredData.Merge(m, src)
 }
-func (m *ParserTestRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ParserTestRequest.DiscardUnknown(m)
+func (m *StructuredData) XXX_Size() int {
+	return xxx_messageInfo_StructuredData.Size(m)
+}
+func (m *StructuredData) XXX_DiscardUnknown() {
+	xxx_messageInfo_StructuredData.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ParserTestRequest proto.InternalMessageInfo
+var xxx_messageInfo_StructuredData proto.InternalMessageInfo
 
-func (m *ParserTestRequest) GetFilePath() string {
+func (m *StructuredData) GetType() string {
 	if m != nil {
-		return m.FilePath
+		return m.Type
 	}
 	return ""
 }
 
-// The response message containing the url's title, body and links of thumbnails.
-type ParserResponse struct {
-	Title                string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	ThumbnailUrl         string   `protobuf:"bytes,2,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
-	Content              string   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *StructuredData) GetHeadline() string {
+	if m != nil {
+		return m.Headline
+	}
+	return ""
 }
 
-func (m *ParserResponse) Reset()         { *m = ParserResponse{} }
-func (m *ParserResponse) String() string { return proto.CompactTextString(m) }
-func (*ParserResponse) ProtoMessage()    {}
-func (*ParserResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_128ea0fcf29414eb, []int{2}
+func (m *StructuredData) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
 }
 
-func (m *ParserResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ParserResponse.Unmarshal(m, b)
-}
-func (m *ParserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ParserResponse.Marshal(b, m, deterministic)
-}
-func (m *ParserResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ParserResponse.Merge(m, src)
+func (m *StructuredData) GetAuthors() []string {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
 }
-func (m *ParserResponse) XXX_Size() int {
-	return xxx_messageInfo_ParserResponse.Size(m)
+
+func (m *StructuredData) GetDatePublished() *timestamp.Timestamp {
+	if m != nil {
+		return m.DatePublished
+	}
+	return nil
 }
-func (m *ParserResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ParserResponse.DiscardUnknown(m)
+
+func (m *StructuredData) GetDateModified() *timestamp.Timestamp {
+	if m != nil {
+		return m.DateModified
+	}
+	return nil
 }
 
-var xxx_messageInfo_ParserResponse proto.InternalMessageInfo
+func (m *StructuredData) GetImages() []string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
 
-func (m *ParserResponse) GetTitle() string {
+func (m *StructuredData) GetArticleBody() string {
 	if m != nil {
-		return m.Title
+		return m.ArticleBody
 	}
 	return ""
 }
 
-func (m *ParserResponse) GetThumbnailUrl() string {
+func (m *StructuredData) GetPublisher() string {
 	if m != nil {
-		return m.ThumbnailUrl
+		return m.Publisher
 	}
 	return ""
 }
 
-func (m *ParserResponse) GetContent() string {
+func (m *StructuredData) GetKeywords() []string {
 	if m != nil {
-		return m.Content
+		return m.Keywords
+	}
+	return nil
+}
+
+func (m *StructuredData) GetUrl() string {
+	if m != nil {
+		return m.Url
 	}
 	return ""
 }
 
 func init() {
+	proto.RegisterEnum("parser.ContentMode", ContentMode_name, ContentMode_value)
+	proto.RegisterEnum("parser.ContentFormat", ContentFormat_name, ContentFormat_value)
+	proto.RegisterEnum("parser.ParseStage", ParseStage_name, ParseStage_value)
+	proto.RegisterEnum("parser.JobState", JobState_name, JobState_value)
+	proto.RegisterEnum("parser.WebhookState", WebhookState_name, WebhookState_value)
+	proto.RegisterEnum("parser.ContentBlock_Type", ContentBlock_Type_name, ContentBlock_Type_value)
 	proto.RegisterType((*ParserRequest)(nil), "parser.ParserRequest")
+	proto.RegisterType((*FetchOptions)(nil), "parser.FetchOptions")
+	proto.RegisterMapType((map[string]string)(nil), "parser.FetchOptions.HeadersEntry")
 	proto.RegisterType((*ParserTestRequest)(nil), "parser.ParserTestRequest")
+	proto.RegisterType((*BatchParserRequest)(nil), "parser.BatchParserRequest")
+	proto.RegisterType((*BatchParserResponse)(nil), "parser.BatchParserResponse")
+	proto.RegisterType((*BatchParserResult)(nil), "parser.BatchParserResult")
+	proto.RegisterType((*ParseStreamRequest)(nil), "parser.ParseStreamRequest")
+	proto.RegisterType((*ParseStreamResponse)(nil), "parser.ParseStreamResponse")
+	proto.RegisterType((*ParseProgressResponse)(nil), "parser.ParseProgressResponse")
+	proto.RegisterType((*ParseJob)(nil), "parser.ParseJob")
+	proto.RegisterType((*WebhookDelivery)(nil), "parser.WebhookDelivery")
+	proto.RegisterType((*GetParseJobRequest)(nil), "parser.GetParseJobRequest")
+	proto.RegisterType((*ListParseJobsRequest)(nil), "parser.ListParseJobsRequest")
+	proto.RegisterType((*ListParseJobsResponse)(nil), "parser.ListParseJobsResponse")
+	proto.RegisterType((*CancelParseJobRequest)(nil), "parser.CancelParseJobRequest")
+	proto.RegisterType((*FeedRequest)(nil), "parser.FeedRequest")
+	proto.RegisterType((*FeedResponse)(nil), "parser.FeedResponse")
+	proto.RegisterType((*FeedEntry)(nil), "parser.FeedEntry")
 	proto.RegisterType((*ParserResponse)(nil), "parser.ParserResponse")
+	proto.RegisterType((*AlternateLink)(nil), "parser.AlternateLink")
+	proto.RegisterType((*ImageCandidate)(nil), "parser.ImageCandidate")
+	proto.RegisterType((*ImageProbe)(nil), "parser.ImageProbe")
+	proto.RegisterType((*ContentBlock)(nil), "parser.ContentBlock")
+	proto.RegisterType((*TableRow)(nil), "parser.TableRow")
+	proto.RegisterType((*OpenGraph)(nil), "parser.OpenGraph")
+	proto.RegisterType((*TwitterCard)(nil), "parser.TwitterCard")
+	proto.RegisterType((*StructuredData)(nil), "parser.StructuredData")
 }
 
 func init() { proto.RegisterFile("parser.proto", fileDescriptor_128ea0fcf29414eb) }
 
 var fileDescriptor_128ea0fcf29414eb = []byte{
-	// 223 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0xc1, 0x4a, 0xc4, 0x30,
-	0x10, 0x86, 0xa9, 0xcb, 0xae, 0x76, 0xd8, 0x15, 0x1d, 0x54, 0xaa, 0x5e, 0xb4, 0x5e, 0x3c, 0x2d,
-	0xa2, 0xe0, 0xd1, 0x67, 0x58, 0xaa, 0x9e, 0x4b, 0x5a, 0x46, 0x1a, 0x88, 0x49, 0x4c, 0xa6, 0xbe,
-	0x82, 0xaf, 0x2d, 0x49, 0x13, 0xb1, 0x82, 0xb7, 0x99, 0x6f, 0xbe, 0x24, 0xf3, 0x07, 0xd6, 0x56,
-	0x38, 0x4f, 0x6e, 0x6b, 0x9d, 0x61, 0x83, 0xab, 0xa9, 0xab, 0xaf, 0x61, 0xb3, 0x8b, 0x55, 0x43,
-	0x1f, 0x23, 0x79, 0xc6, 0x23, 0x58, 0x8c, 0x4e, 0x55, 0xc5, 0x55, 0x71, 0x5b, 0x36, 0xa1, 0xac,
-	0xef, 0xe0, 0x78, 0x52, 0x5e, 0xc8, 0x73, 0xd6, 0x2e, 0xa1, 0x7c, 0x93, 0x8a, 0x5a, 0x2b, 0x78,
-	0x48, 0xf2, 0x41, 0x00, 0x3b, 0xc1, 0x43, 0x4d, 0x70, 0x98, 0x2f, 0xf5, 0xd6, 0x68, 0x4f, 0x78,
-	0x02, 0x4b, 0x96, 0xac, 0x28, 0xa9, 0x53, 0x83, 0x37, 0xb0, 0xe1, 0x61, 0x7c, 0xef, 0xb4, 0x90,
-	0xaa, 0x0d, 0xaf, 0xee, 0xc5, 0xe9, 0xfa, 0x07, 0xbe, 0x3a, 0x85, 0x15, 0xec, 0xf7, 0x46, 0x33,
-	0x69, 0xae, 0x16, 0x71, 0x9c, 0xdb, 0xfb, 0xaf, 0x22, 0x2f, 0xff, 0x4c, 0xee, 0x53, 0xf6, 0x84,
-	0x8f, 0xb0, 0x8c, 0x00, 0x4f, 0xb7, 0x29, 0xed, 0x2c, 0xdc, 0xc5, 0xd9, 0x5f, 0x9c, 0xd6, 0x7b,
-	0x82, 0x32, 0x92, 0x90, 0x10, 0xcf, 0xe7, 0xd2, 0xaf, 0xd4, 0xff, 0x9d, 0xef, 0x56, 0xf1, 0x53,
-	0x1f, 0xbe, 0x03, 0x00, 0x00, 0xff, 0xff, 0x27, 0x60, 0x63, 0x5d, 0x64, 0x01, 0x00, 0x00,
+	// 2783 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x39, 0x4b, 0x6f, 0xe3, 0xd6,
+	0xb9, 0xa1, 0xde, 0xfa, 0xf4, 0x30, 0xe7, 0xd8, 0x1e, 0x73, 0x3c, 0x33, 0x89, 0x47, 0x09, 0x12,
+	0xdf, 0xc9, 0xbd, 0xce, 0xc4, 0xb9, 0x18, 0xe4, 0x26, 0xb7, 0x49, 0x65, 0x89, 0x1e, 0x7b, 0x46,
+	0x7e, 0x84, 0x96, 0x9b, 0x66, 0x51, 0x10, 0x47, 0xe4, 0xb1, 0xc5, 0x98, 0x22, 0x55, 0xf2, 0x68,
+	0x6c, 0x67, 0xd3, 0x45, 0x17, 0xdd, 0x15, 0x5d, 0x17, 0x28, 0xd0, 0x45, 0xd0, 0x55, 0xff, 0x40,
+	0xfb, 0x1b, 0xfa, 0x03, 0x8a, 0x02, 0xfd, 0x03, 0xfd, 0x0d, 0x5d, 0x14, 0xe7, 0x45, 0x91, 0x92,
+	0x3c, 0x36, 0xb2, 0x2b, 0xba, 0xd3, 0xf7, 0xe4, 0x77, 0xbe, 0xf7, 0x39, 0x82, 0xfa, 0x18, 0x47,
+	0x31, 0x89, 0xb6, 0xc6, 0x51, 0x48, 0x43, 0x54, 0x12, 0xd0, 0xfa, 0xdb, 0xe7, 0x61, 0x78, 0xee,
+	0x93, 0x8f, 0x38, 0x76, 0x30, 0x39, 0xfb, 0xc8, 0x9d, 0x44, 0x98, 0x7a, 0x61, 0x20, 0xf8, 0xd6,
+	0xdf, 0x99, 0xa5, 0x53, 0x6f, 0x44, 0x62, 0x8a, 0x47, 0x63, 0xc1, 0xd0, 0xfa, 0x53, 0x0e, 0x1a,
+	0xc7, 0x5c, 0x97, 0x45, 0x7e, 0x3e, 0x21, 0x31, 0x45, 0x3a, 0xe4, 0x27, 0x91, 0x6f, 0x68, 0x1b,
+	0xda, 0x66, 0xd5, 0x62, 0x3f, 0xd1, 0x73, 0xa8, 0x3b, 0x61, 0x40, 0x49, 0x40, 0xed, 0x51, 0xe8,
+	0x12, 0x23, 0xb7, 0xa1, 0x6d, 0x36, 0xb7, 0x97, 0xb7, 0xa4, 0x45, 0x1d, 0x41, 0x3b, 0x08, 0x5d,
+	0x62, 0xd5, 0x9c, 0x29, 0x80, 0xbe, 0x80, 0x25, 0x25, 0x77, 0x16, 0x46, 0x23, 0x4c, 0x63, 0x23,
+	0xbf, 0x91, 0xdf, 0x6c, 0x6e, 0xaf, 0xce, 0x88, 0xee, 0x72, 0xaa, 0xd5, 0x74, 0xd2, 0x60, 0x8c,
+	0x1e, 0x03, 0x8c, 0xf0, 0x95, 0xed, 0x8d, 0xf0, 0x39, 0x89, 0x8d, 0xc2, 0x86, 0xb6, 0x59, 0xb4,
+	0xaa, 0x23, 0x7c, 0xb5, 0xcf, 0x11, 0xe8, 0x09, 0xd4, 0xc7, 0x51, 0x38, 0x20, 0x8a, 0xa1, 0xb8,
+	0xa1, 0x6d, 0x56, 0xac, 0x1a, 0xc7, 0x49, 0x96, 0xff, 0x83, 0xc6, 0x19, 0xa1, 0xce, 0xd0, 0x0e,
+	0xc7, 0xcc, 0x29, 0xb1, 0x51, 0xda, 0xd0, 0x36, 0x6b, 0xdb, 0x2b, 0xea, 0xfb, 0xbb, 0x8c, 0x78,
+	0x24, 0x68, 0x56, 0xfd, 0x2c, 0x05, 0x31, 0xed, 0x0e, 0xf6, 0xfd, 0x01, 0x76, 0x2e, 0x6c, 0xe6,
+	0x8f, 0x32, 0xf7, 0x47, 0x4d, 0xe1, 0x4e, 0x23, 0xbf, 0xf5, 0xc7, 0x3c, 0xd4, 0xd3, 0x1a, 0xd0,
+	0x27, 0x50, 0x66, 0xfe, 0x0d, 0x27, 0x94, 0xbb, 0xaf, 0xb6, 0xfd, 0x60, 0x4b, 0xf8, 0x7f, 0x4b,
+	0xf9, 0x7f, 0xab, 0x2b, 0xe3, 0x63, 0x29, 0x4e, 0xf4, 0x1e, 0x34, 0xd9, 0x29, 0x07, 0xa1, 0x7b,
+	0x6d, 0x0f, 0xae, 0x29, 0x89, 0xb9, 0x7f, 0xf3, 0x56, 0x7d, 0x84, 0xaf, 0x76, 0x42, 0xf7, 0x7a,
+	0x87, 0xe1, 0x98, 0x2f, 0x26, 0x31, 0x89, 0x6c, 0x7c, 0x4e, 0x02, 0x6a, 0xe4, 0xb9, 0x31, 0x55,
+	0x86, 0x69, 0x33, 0x04, 0xfa, 0x00, 0x96, 0xb0, 0xe3, 0x90, 0x31, 0xb5, 0x7d, 0x1c, 0x9c, 0x4f,
+	0xf0, 0x39, 0xe1, 0xfe, 0xaa, 0x5a, 0x4d, 0x81, 0xee, 0x49, 0x2c, 0xfa, 0x1c, 0xca, 0x43, 0x82,
+	0x5d, 0x12, 0x31, 0x7f, 0xe5, 0x37, 0x6b, 0xdb, 0x4f, 0x16, 0xf9, 0x62, 0x6b, 0x4f, 0xf0, 0x98,
+	0x01, 0x8d, 0xae, 0x2d, 0x25, 0x81, 0x76, 0x78, 0x40, 0x03, 0xe2, 0x50, 0x5b, 0x9d, 0xb3, 0x74,
+	0xdb, 0x39, 0x9b, 0x52, 0xa2, 0x2f, 0x8f, 0xfb, 0xff, 0x50, 0x8f, 0x08, 0x76, 0x13, 0x05, 0xe5,
+	0xdb, 0x14, 0xd4, 0x18, 0xbb, 0x94, 0x5e, 0xff, 0x0c, 0xea, 0x69, 0xd3, 0x58, 0xb2, 0x5e, 0x90,
+	0x6b, 0x95, 0xac, 0x17, 0xe4, 0x1a, 0xad, 0x40, 0xf1, 0x35, 0xf6, 0x27, 0x22, 0x4b, 0xab, 0x96,
+	0x00, 0x3e, 0xcb, 0x7d, 0xaa, 0xb5, 0xfe, 0xa1, 0xc1, 0x3d, 0x91, 0xea, 0x7d, 0x12, 0x53, 0x95,
+	0xee, 0x0f, 0xa1, 0x7a, 0xe6, 0xf9, 0xc4, 0x1e, 0x63, 0x3a, 0x94, 0x7a, 0x2a, 0x0c, 0x71, 0x8c,
+	0xe9, 0xf0, 0xdf, 0x37, 0xf3, 0x5b, 0xbf, 0xcb, 0x01, 0xda, 0xc1, 0xd4, 0x19, 0x66, 0x8b, 0x1b,
+	0x41, 0x61, 0x12, 0xf9, 0xb1, 0xa1, 0x6d, 0xe4, 0x37, 0xab, 0x16, 0xff, 0xfd, 0x1f, 0x59, 0xde,
+	0xad, 0x97, 0xb0, 0x9c, 0x71, 0x4f, 0x3c, 0x0e, 0x83, 0x98, 0xb0, 0x0a, 0x8e, 0x48, 0x3c, 0xf1,
+	0xa9, 0x70, 0x11, 0x4b, 0x4c, 0xa9, 0x2b, 0xcb, 0x3d, 0xf1, 0xa9, 0xa5, 0x38, 0x5b, 0xbf, 0xd4,
+	0xe0, 0xde, 0x1c, 0x79, 0x41, 0x1f, 0x45, 0x50, 0x70, 0x94, 0x83, 0x8b, 0x16, 0xff, 0xcd, 0xd2,
+	0x95, 0x44, 0x51, 0x18, 0xc9, 0x92, 0x16, 0x00, 0xda, 0x86, 0x4a, 0x24, 0x4d, 0xe2, 0x8e, 0xa9,
+	0x6d, 0xdf, 0x57, 0x76, 0x64, 0x0d, 0xb6, 0x12, 0xbe, 0xd6, 0x29, 0x20, 0x4e, 0x3b, 0xa1, 0x11,
+	0xc1, 0x23, 0x15, 0xf0, 0x26, 0xe4, 0x3c, 0x57, 0x1a, 0x91, 0xf3, 0x5c, 0xf4, 0x11, 0x3b, 0x20,
+	0x27, 0x71, 0x33, 0x6a, 0xd3, 0x60, 0x65, 0x12, 0xc5, 0x52, 0x5c, 0xad, 0x9f, 0xc2, 0x72, 0x46,
+	0xad, 0x74, 0xd4, 0xac, 0xde, 0x8f, 0xa1, 0x24, 0xdc, 0x21, 0xd5, 0xbe, 0xc1, 0x6f, 0x92, 0xb1,
+	0x35, 0x81, 0x55, 0x8e, 0x3f, 0x8e, 0xc2, 0xf3, 0x88, 0xc4, 0x71, 0xa2, 0x7b, 0x13, 0x8a, 0x31,
+	0x65, 0x2d, 0x4c, 0xe3, 0x99, 0x88, 0x32, 0x16, 0x9e, 0x30, 0x8a, 0x25, 0x18, 0x32, 0x7e, 0xca,
+	0xdd, 0xd1, 0x4f, 0xdf, 0xe7, 0xa1, 0xc2, 0x89, 0x2f, 0xc3, 0xc1, 0xdc, 0x31, 0x64, 0xd0, 0x72,
+	0xd3, 0xa0, 0xbd, 0xcf, 0x8d, 0xa1, 0x84, 0x07, 0xa8, 0xb9, 0xad, 0x2b, 0xfd, 0x2f, 0xc3, 0xc1,
+	0x09, 0xc3, 0x5b, 0x82, 0x8c, 0x7e, 0x04, 0x75, 0x27, 0x22, 0x98, 0x12, 0xd1, 0xda, 0x64, 0xd8,
+	0xd6, 0xe7, 0xfa, 0x5a, 0x5f, 0x0d, 0x60, 0xab, 0x26, 0xf9, 0x19, 0x86, 0x89, 0xc7, 0x14, 0x47,
+	0x89, 0x78, 0xf1, 0x76, 0x71, 0xc9, 0xcf, 0xc5, 0xbf, 0x84, 0xc6, 0x99, 0x17, 0x78, 0xf1, 0x50,
+	0xc9, 0x97, 0x6e, 0x95, 0xaf, 0x2b, 0x01, 0xae, 0x40, 0xe5, 0x66, 0x79, 0x51, 0x6e, 0x56, 0x6e,
+	0xca, 0xcd, 0xea, 0xdd, 0x7c, 0x8e, 0x3e, 0x86, 0xf2, 0x25, 0x19, 0x0c, 0xc3, 0xf0, 0xc2, 0x00,
+	0x2e, 0xb2, 0xa6, 0x44, 0xbe, 0x16, 0xe8, 0x2e, 0xf1, 0xbd, 0xd7, 0x84, 0xcd, 0x1a, 0xc9, 0xd7,
+	0xfa, 0xa7, 0x06, 0x4b, 0x33, 0xc4, 0x05, 0x25, 0xf5, 0x54, 0x45, 0x47, 0x34, 0xad, 0x95, 0x19,
+	0xb5, 0x99, 0x08, 0xad, 0x43, 0x05, 0x53, 0x4a, 0x46, 0x63, 0xde, 0xa8, 0xd8, 0x31, 0x13, 0x18,
+	0xed, 0xc2, 0x3d, 0x1f, 0xc7, 0xd4, 0x96, 0x88, 0xbb, 0x86, 0x70, 0x89, 0x09, 0xb5, 0x85, 0x0c,
+	0x77, 0xe3, 0x26, 0xe8, 0x5c, 0x0f, 0xfb, 0xe2, 0x24, 0xb6, 0xb9, 0x4b, 0x8b, 0xfc, 0x5b, 0x4d,
+	0x86, 0x3f, 0xe1, 0xe8, 0x0e, 0x73, 0xee, 0x63, 0x00, 0xce, 0x29, 0x3c, 0x5c, 0x12, 0x03, 0x9d,
+	0x61, 0x4c, 0x86, 0x68, 0xbd, 0x07, 0xe8, 0x05, 0xa1, 0x2a, 0x4f, 0x6f, 0xa8, 0xe6, 0xd6, 0x77,
+	0xb0, 0xd2, 0xf3, 0xe2, 0x84, 0x2d, 0x56, 0x7c, 0x49, 0xd2, 0x6a, 0x6f, 0x4e, 0xda, 0x87, 0x50,
+	0x1d, 0xe3, 0x73, 0x62, 0xc7, 0xde, 0x77, 0xaa, 0x2d, 0x55, 0x18, 0xe2, 0xc4, 0xfb, 0x8e, 0x5b,
+	0xc8, 0x89, 0x34, 0xbc, 0x20, 0x81, 0x5a, 0x39, 0x18, 0xa6, 0xcf, 0x10, 0x2d, 0x02, 0xab, 0x33,
+	0xdf, 0x96, 0xc1, 0x7e, 0x0f, 0x0a, 0xdf, 0x86, 0x03, 0xd5, 0x40, 0xf5, 0x4c, 0x72, 0xb0, 0xb3,
+	0x70, 0x2a, 0x7a, 0x1f, 0x96, 0x02, 0x72, 0x45, 0xed, 0xd4, 0x27, 0x44, 0xd5, 0x35, 0x18, 0xfa,
+	0x38, 0xf9, 0xcc, 0x07, 0xb0, 0xda, 0xc1, 0x81, 0x43, 0xfc, 0xdb, 0x7c, 0xf1, 0xb7, 0x1c, 0xd4,
+	0x76, 0x09, 0x71, 0x6f, 0xde, 0x63, 0xdf, 0x81, 0x1a, 0x1b, 0x38, 0x24, 0xa0, 0x91, 0x27, 0xd7,
+	0xac, 0xa2, 0xc5, 0x66, 0x90, 0x29, 0x30, 0xe8, 0x5d, 0x68, 0x70, 0x63, 0x13, 0x96, 0x3c, 0x9f,
+	0x39, 0x62, 0xf5, 0x56, 0x4c, 0xb3, 0xe3, 0xb2, 0xf0, 0xc3, 0xc7, 0x65, 0xf1, 0x87, 0x8f, 0xcb,
+	0xd2, 0x6d, 0xe3, 0xb2, 0x7c, 0x87, 0x71, 0x59, 0xb9, 0xf3, 0xb8, 0xfc, 0x7d, 0x0e, 0xea, 0xc2,
+	0xb9, 0x32, 0xc8, 0xf3, 0xde, 0xbd, 0x0f, 0x25, 0x71, 0x2e, 0x19, 0x47, 0x09, 0xb1, 0x2e, 0x42,
+	0x3d, 0xea, 0x13, 0x35, 0xe1, 0x38, 0x80, 0x36, 0xa0, 0xe6, 0x92, 0xd8, 0x89, 0x3c, 0xfe, 0x01,
+	0xb9, 0xac, 0xa6, 0x51, 0xac, 0x23, 0xf9, 0x5e, 0x70, 0xc1, 0xcb, 0xa7, 0x6a, 0xf1, 0xdf, 0xac,
+	0x84, 0x93, 0xfd, 0x56, 0x94, 0x4c, 0x02, 0xb3, 0x5c, 0xe6, 0x47, 0x4f, 0x6d, 0xeb, 0x15, 0x8e,
+	0x38, 0x8d, 0x7c, 0xf4, 0xbf, 0x50, 0x9e, 0x8c, 0x5d, 0xd6, 0x6d, 0x8d, 0xca, 0xad, 0x55, 0xad,
+	0x58, 0xd1, 0x87, 0x50, 0x56, 0x99, 0x50, 0xe5, 0xc9, 0x7c, 0x6f, 0xea, 0x2a, 0xe2, 0xca, 0xe5,
+	0x58, 0x72, 0xb4, 0xfe, 0x9e, 0x83, 0x6a, 0x82, 0x9e, 0x1b, 0x2c, 0x89, 0x17, 0x72, 0x69, 0x2f,
+	0xa8, 0x33, 0xe6, 0x53, 0x67, 0xfc, 0x14, 0xaa, 0xe3, 0xc9, 0xc0, 0xe7, 0xad, 0xf9, 0x0e, 0x2d,
+	0x68, 0xca, 0x9c, 0x3e, 0x64, 0xf1, 0xee, 0x87, 0x34, 0xa0, 0x1c, 0x4f, 0x46, 0x23, 0x1c, 0x5d,
+	0x4b, 0x97, 0x2a, 0xf0, 0xcd, 0x1e, 0x35, 0xa0, 0x8c, 0x27, 0x74, 0x18, 0x46, 0x2c, 0x8d, 0xd8,
+	0x32, 0xa9, 0x40, 0xf4, 0x0c, 0xca, 0x38, 0xa2, 0x9e, 0xe3, 0xdf, 0x36, 0x1f, 0x14, 0x1b, 0xab,
+	0x3b, 0xf9, 0x53, 0xb6, 0x43, 0xe0, 0x1f, 0xab, 0x4b, 0xa4, 0xe8, 0x88, 0xbf, 0x06, 0x68, 0x66,
+	0x15, 0x4c, 0x9d, 0xaa, 0xa5, 0x9d, 0xfa, 0x2e, 0x34, 0xe8, 0x70, 0x32, 0x1a, 0x04, 0xd8, 0xf3,
+	0xed, 0xe9, 0x34, 0xaf, 0x27, 0x48, 0x69, 0xbe, 0xac, 0x2f, 0xe9, 0x7c, 0x05, 0xb2, 0x53, 0x0f,
+	0x71, 0x6c, 0x0b, 0xc5, 0x4b, 0xbc, 0x8a, 0x2a, 0x43, 0x1c, 0xf7, 0x95, 0x6e, 0x4e, 0x54, 0xaa,
+	0x0c, 0x5d, 0x74, 0x08, 0xc6, 0xa0, 0x70, 0xac, 0xcf, 0x30, 0x26, 0xa5, 0xff, 0x1e, 0x67, 0x81,
+	0x21, 0x8e, 0x65, 0x81, 0xdf, 0x21, 0xf9, 0xdf, 0x85, 0x86, 0x83, 0x83, 0x30, 0xf0, 0x1c, 0x2c,
+	0xce, 0x20, 0xaa, 0xa0, 0x9e, 0x20, 0xd9, 0x19, 0x1e, 0x42, 0x35, 0xf6, 0x28, 0xb1, 0x03, 0x3c,
+	0x4a, 0xca, 0x81, 0x21, 0x0e, 0xf1, 0x88, 0xa4, 0xe3, 0x53, 0xce, 0xc6, 0xa7, 0x0d, 0xcd, 0x24,
+	0x67, 0xc4, 0xa0, 0xbb, 0xbd, 0x24, 0x1a, 0x89, 0x84, 0x5a, 0x37, 0x46, 0xa1, 0xeb, 0x9d, 0x79,
+	0x4a, 0x43, 0xf5, 0xf6, 0x75, 0x43, 0x09, 0x70, 0x05, 0xe9, 0x42, 0x86, 0x99, 0x42, 0x5e, 0x87,
+	0xca, 0x05, 0xb9, 0xbe, 0x0c, 0x23, 0x37, 0x36, 0x6a, 0xdc, 0xf4, 0x04, 0x66, 0xae, 0x3d, 0xc3,
+	0xaf, 0x3d, 0x27, 0x0c, 0xb8, 0x57, 0xea, 0x5c, 0x14, 0x24, 0x8a, 0xf9, 0xe4, 0x31, 0x00, 0xe3,
+	0xb4, 0x9d, 0x70, 0x12, 0x50, 0xa3, 0x21, 0xba, 0x24, 0xc3, 0x74, 0x18, 0x42, 0xdc, 0xf6, 0x02,
+	0xe9, 0xd3, 0xa6, 0xba, 0xed, 0x05, 0xc2, 0x9f, 0xcf, 0x00, 0xc2, 0x31, 0x09, 0xec, 0xf3, 0x08,
+	0x8f, 0x87, 0x06, 0xda, 0xd0, 0xd2, 0x15, 0x7f, 0x34, 0x26, 0xc1, 0x0b, 0x46, 0xb0, 0xaa, 0xa1,
+	0xfa, 0xc9, 0x66, 0x01, 0xbd, 0xf4, 0x28, 0x25, 0x91, 0xed, 0xe0, 0xc8, 0x35, 0x96, 0xb9, 0x4c,
+	0x32, 0x0b, 0xfa, 0x82, 0xd6, 0xc1, 0x91, 0x6b, 0xd5, 0xe8, 0x14, 0x40, 0x5f, 0xc2, 0x52, 0x4c,
+	0xa3, 0x89, 0x43, 0x27, 0x11, 0x71, 0x6d, 0x17, 0x53, 0x6c, 0xac, 0x64, 0x4b, 0xe5, 0x24, 0x21,
+	0x77, 0x31, 0xc5, 0x56, 0x33, 0xce, 0xc0, 0x68, 0x0d, 0xca, 0xdf, 0xc6, 0x61, 0x60, 0xfb, 0xae,
+	0xb1, 0xca, 0x5d, 0x54, 0x62, 0x60, 0xcf, 0x45, 0x8f, 0xa0, 0x4a, 0xae, 0x68, 0x84, 0x1d, 0x1a,
+	0x46, 0xc6, 0x7d, 0x31, 0xb3, 0x13, 0x04, 0xfa, 0x6f, 0x28, 0x0d, 0xfc, 0xd0, 0xb9, 0x88, 0x8d,
+	0xb5, 0x8d, 0x7c, 0xba, 0xf5, 0xcb, 0xcc, 0xdc, 0x61, 0x44, 0x4b, 0xf2, 0xa0, 0xff, 0x02, 0x3d,
+	0x99, 0x74, 0x38, 0xba, 0x70, 0xc3, 0xcb, 0xc0, 0x30, 0xb8, 0x4a, 0x35, 0xc9, 0x0e, 0x24, 0x9a,
+	0xbf, 0x96, 0x48, 0xd6, 0x21, 0x1d, 0xf9, 0xc6, 0x03, 0xf9, 0x5a, 0x22, 0x70, 0x7b, 0x74, 0xe4,
+	0xa3, 0x2d, 0x28, 0xc9, 0xd1, 0xb4, 0xbe, 0x91, 0x4f, 0x1f, 0x95, 0x4f, 0xa7, 0x0e, 0x0e, 0x5c,
+	0x8f, 0x35, 0x24, 0x4b, 0x72, 0xa1, 0xcf, 0x61, 0x69, 0x5a, 0xc6, 0x7c, 0x8c, 0x19, 0x0f, 0xb9,
+	0x8f, 0x50, 0x46, 0xf0, 0x98, 0x51, 0xac, 0x66, 0xc2, 0xca, 0x61, 0x5e, 0xde, 0x43, 0xc6, 0x45,
+	0x8d, 0x47, 0xb2, 0xbc, 0x05, 0x98, 0xb6, 0x94, 0x5e, 0x8f, 0x89, 0xf1, 0x38, 0x63, 0x69, 0xff,
+	0x7a, 0x3c, 0x5d, 0x7c, 0x44, 0x0e, 0xbd, 0x2d, 0x72, 0x88, 0x61, 0x44, 0x0e, 0xad, 0x41, 0x19,
+	0x8f, 0xc6, 0x3c, 0x83, 0xde, 0x11, 0x93, 0x0e, 0x8f, 0xc6, 0x2c, 0x7f, 0x3e, 0x84, 0xe2, 0x19,
+	0x21, 0x6e, 0x6c, 0x6c, 0xf0, 0x03, 0x26, 0x73, 0xbd, 0xed, 0x53, 0x12, 0x05, 0x98, 0x92, 0x9e,
+	0x17, 0x5c, 0x58, 0x82, 0x07, 0xfd, 0x18, 0xf4, 0x90, 0x8c, 0x06, 0xc4, 0xb5, 0x49, 0xe0, 0x8e,
+	0x43, 0x2f, 0xa0, 0xb1, 0xf1, 0xe4, 0x4d, 0x72, 0x4b, 0x82, 0xdd, 0x54, 0xdc, 0xad, 0x57, 0xd0,
+	0xc8, 0x70, 0x2c, 0xbe, 0x71, 0xf2, 0x43, 0x8a, 0x0e, 0xc8, 0x7f, 0x2f, 0x9e, 0xc7, 0xad, 0xbf,
+	0x6a, 0xd0, 0xcc, 0x06, 0x62, 0x81, 0xba, 0x15, 0x28, 0xc6, 0x4e, 0x18, 0x09, 0x7d, 0x9a, 0x25,
+	0x00, 0xe6, 0xeb, 0x88, 0xe0, 0x38, 0x0c, 0xc4, 0xfd, 0xbf, 0x6a, 0x29, 0x90, 0xf1, 0x5f, 0x7a,
+	0x2e, 0x1d, 0xca, 0xcb, 0xbd, 0x00, 0xd8, 0xa2, 0x30, 0x24, 0xde, 0xf9, 0x90, 0xca, 0xcd, 0x58,
+	0x42, 0xec, 0x7b, 0xd8, 0xa7, 0xb2, 0x91, 0xb1, 0x9f, 0xec, 0x22, 0x28, 0x02, 0x5f, 0xbe, 0x31,
+	0xf0, 0x82, 0x81, 0xf5, 0x8c, 0x88, 0x7c, 0x4b, 0x1c, 0x35, 0xe0, 0x2b, 0x56, 0x02, 0xb7, 0x7e,
+	0x06, 0x30, 0x15, 0x98, 0x8b, 0xbf, 0x36, 0x1f, 0xff, 0xc4, 0xec, 0xdc, 0x62, 0xb3, 0xf3, 0x69,
+	0xb3, 0x5b, 0x7f, 0xc8, 0x41, 0x3d, 0x5d, 0x3e, 0xe8, 0x7f, 0xa0, 0x90, 0x68, 0x6e, 0x4e, 0x2f,
+	0xc2, 0x69, 0x9e, 0x2d, 0xf6, 0x1d, 0x19, 0x0f, 0x16, 0x23, 0x72, 0x45, 0x93, 0x18, 0x91, 0x2b,
+	0xbe, 0x33, 0xf9, 0xe4, 0x35, 0xf1, 0xe5, 0xa7, 0x04, 0xc0, 0x1c, 0x1d, 0x46, 0x2e, 0x89, 0xe4,
+	0x5e, 0x50, 0xb1, 0x14, 0xc8, 0xf8, 0x3d, 0x4a, 0x46, 0x62, 0xa3, 0xac, 0x5a, 0x02, 0x60, 0x8b,
+	0x78, 0x14, 0x5e, 0xb2, 0x5d, 0x31, 0xb3, 0x88, 0xf7, 0xf1, 0xc0, 0x27, 0x56, 0x78, 0x69, 0x71,
+	0x6a, 0xeb, 0x1c, 0x0a, 0xf2, 0xd4, 0x7a, 0xff, 0x9b, 0x63, 0xd3, 0x3e, 0x3d, 0x3c, 0x39, 0x36,
+	0x3b, 0xfb, 0xbb, 0xfb, 0x66, 0x57, 0x7f, 0x0b, 0x35, 0xa0, 0x7a, 0xdc, 0xb6, 0xda, 0x2f, 0xac,
+	0xf6, 0xf1, 0x9e, 0xae, 0xa1, 0x1a, 0x94, 0xf7, 0xcc, 0x76, 0x77, 0xff, 0xf0, 0x85, 0x9e, 0x43,
+	0x15, 0x28, 0xf4, 0xf6, 0x4f, 0xfa, 0x7a, 0x1e, 0x35, 0x01, 0x76, 0x7a, 0x47, 0x9d, 0x57, 0x5f,
+	0x9d, 0x1e, 0xf5, 0x4d, 0xbd, 0xc0, 0x28, 0x9d, 0xa3, 0xae, 0xa9, 0x17, 0x51, 0x15, 0x8a, 0xfd,
+	0xf6, 0x4e, 0xcf, 0xd4, 0x4b, 0xad, 0x0d, 0xa8, 0xa8, 0x4f, 0x33, 0x83, 0x1d, 0xe2, 0x27, 0x0f,
+	0x51, 0x02, 0x68, 0xfd, 0x45, 0x83, 0x6a, 0xd2, 0x67, 0x6f, 0x98, 0xee, 0x8b, 0x52, 0x5a, 0x66,
+	0x6a, 0x7e, 0x9a, 0xa9, 0xb7, 0x4f, 0xd8, 0xcc, 0xf0, 0x2c, 0xce, 0x0c, 0xcf, 0xfb, 0x50, 0xf2,
+	0x43, 0x07, 0xfb, 0x6a, 0xac, 0x4a, 0x88, 0xe1, 0x93, 0xf5, 0x9a, 0x77, 0x5d, 0x01, 0xf1, 0x47,
+	0x47, 0xcf, 0x25, 0xa1, 0xba, 0x29, 0x73, 0xa0, 0xf5, 0x67, 0x0d, 0x6a, 0xa9, 0x11, 0xc0, 0xef,
+	0xd8, 0x6c, 0x4a, 0x88, 0xf3, 0x14, 0x1c, 0x89, 0x63, 0x5f, 0x55, 0xc7, 0x61, 0xbf, 0x79, 0xf3,
+	0x8a, 0x08, 0xa6, 0xc9, 0xab, 0x90, 0x02, 0xa7, 0x2e, 0x29, 0xbc, 0x61, 0x97, 0x2e, 0xce, 0x1f,
+	0x96, 0xe5, 0xc7, 0x68, 0xba, 0x34, 0x0b, 0x60, 0xba, 0xdf, 0xb1, 0xb2, 0x4b, 0xef, 0x77, 0x6d,
+	0x9f, 0xb6, 0x7e, 0x95, 0x87, 0x66, 0x76, 0x08, 0x25, 0xae, 0xd7, 0x52, 0xae, 0x5f, 0x87, 0x0a,
+	0x7b, 0x1d, 0xf6, 0xbd, 0x40, 0x9d, 0x21, 0x81, 0x67, 0xed, 0xca, 0xcf, 0xdb, 0x95, 0x5a, 0x52,
+	0x0a, 0x73, 0x4b, 0x0a, 0x6b, 0x42, 0xf6, 0x74, 0x15, 0xbe, 0x7d, 0xa5, 0x6d, 0x30, 0x89, 0x63,
+	0x25, 0xc0, 0x96, 0x14, 0xae, 0x42, 0x2d, 0x1e, 0x77, 0x79, 0x13, 0x61, 0x02, 0x07, 0x92, 0xff,
+	0xc6, 0x68, 0x3f, 0x01, 0xb5, 0x99, 0xf2, 0x57, 0x7b, 0x19, 0xf4, 0x9a, 0xc4, 0xb1, 0x37, 0x7b,
+	0x36, 0x86, 0x95, 0xe5, 0x91, 0x51, 0x95, 0x57, 0x67, 0x85, 0xc8, 0x6c, 0x38, 0x30, 0xb3, 0xe1,
+	0xc8, 0x5c, 0xae, 0x25, 0xb9, 0xfc, 0xd4, 0x84, 0x5a, 0xea, 0x52, 0x89, 0x0c, 0x58, 0xe9, 0x1c,
+	0x1d, 0xf6, 0xcd, 0xc3, 0xbe, 0x7d, 0x70, 0xd4, 0x35, 0xed, 0xae, 0xb9, 0xdb, 0x3e, 0xed, 0xf5,
+	0xf5, 0xb7, 0xd0, 0x23, 0x30, 0x32, 0x14, 0xcb, 0x6c, 0x77, 0xdb, 0x3b, 0xfb, 0xbd, 0xfd, 0xfe,
+	0x37, 0xba, 0xf6, 0x94, 0x40, 0x23, 0x73, 0xc1, 0x44, 0x6f, 0xc3, 0xba, 0x62, 0xdf, 0x3d, 0xb2,
+	0x0e, 0xda, 0xfd, 0x99, 0xd2, 0x7f, 0x08, 0x6b, 0x33, 0xf4, 0x83, 0xb6, 0xf5, 0xaa, 0x7b, 0xf4,
+	0xf5, 0xa1, 0xae, 0xa1, 0x35, 0x58, 0x9e, 0x21, 0xee, 0xf5, 0x0f, 0x7a, 0x7a, 0xee, 0xe9, 0x6f,
+	0x34, 0x80, 0xe9, 0x43, 0x1d, 0x53, 0x72, 0xdc, 0xb6, 0x4e, 0x4c, 0xfb, 0xa4, 0xdf, 0x7e, 0x31,
+	0xdb, 0x5c, 0xd6, 0x60, 0x39, 0x4d, 0xdc, 0x35, 0xfb, 0x9d, 0x3d, 0xb3, 0xab, 0x6b, 0xec, 0x8c,
+	0x69, 0xc2, 0x81, 0xd9, 0x6f, 0x77, 0xdb, 0xfd, 0xb6, 0x9e, 0x43, 0x0f, 0x60, 0x35, 0x4d, 0xe9,
+	0xef, 0x9d, 0x1e, 0xec, 0x1c, 0xb6, 0xf7, 0x7b, 0x7a, 0x7e, 0x56, 0x9b, 0x34, 0x4f, 0x2f, 0x3c,
+	0xfd, 0xad, 0x06, 0x15, 0xf5, 0xf2, 0xc1, 0x14, 0xbc, 0x3c, 0xda, 0x61, 0x3c, 0xfd, 0x59, 0x73,
+	0x56, 0x40, 0x9f, 0x92, 0xbe, 0x3a, 0x35, 0x4f, 0xb9, 0x2d, 0xab, 0x70, 0x6f, 0x8a, 0xb5, 0x4e,
+	0x0f, 0x0f, 0x45, 0xf3, 0x5b, 0x83, 0xe5, 0x29, 0xfa, 0xe4, 0xb4, 0xd3, 0x31, 0xcd, 0xae, 0xd9,
+	0xd5, 0xf3, 0x59, 0x2d, 0xbb, 0xed, 0xfd, 0x9e, 0xd9, 0xd5, 0x0b, 0x59, 0xf6, 0x4e, 0xfb, 0xb0,
+	0x63, 0xf6, 0x18, 0xa1, 0xf8, 0xf4, 0x17, 0x50, 0x4f, 0x3f, 0x56, 0xa1, 0xc7, 0xf0, 0xe0, 0x6b,
+	0x73, 0x67, 0xef, 0xe8, 0xe8, 0xd5, 0x42, 0x1b, 0x1f, 0xc0, 0x6a, 0x96, 0x7c, 0x6c, 0x1e, 0xf2,
+	0x76, 0xac, 0x31, 0x57, 0x67, 0x49, 0x5d, 0xb3, 0xb7, 0xff, 0x13, 0xd3, 0x32, 0xbb, 0x7a, 0x8e,
+	0x79, 0x34, 0x4b, 0x94, 0x96, 0xe5, 0xb7, 0xbf, 0x2f, 0xaa, 0x7f, 0x00, 0x4f, 0x48, 0xf4, 0xda,
+	0x73, 0x08, 0x7a, 0x0e, 0x45, 0x8e, 0x40, 0x8b, 0xdf, 0x86, 0xd7, 0x6f, 0xb8, 0xcf, 0xa1, 0x2f,
+	0xa0, 0xca, 0x31, 0xec, 0xef, 0x15, 0xf4, 0x20, 0xcb, 0x94, 0xfa, 0xcb, 0xe5, 0x46, 0xf9, 0xe7,
+	0x52, 0x9e, 0xdd, 0xa2, 0xd1, 0x72, 0xfa, 0xaa, 0xad, 0x24, 0x57, 0xb2, 0x48, 0x29, 0x67, 0x02,
+	0x4c, 0x5f, 0x99, 0xd1, 0xfa, 0xc2, 0x97, 0x67, 0x21, 0xff, 0x70, 0x21, 0x4d, 0xaa, 0x79, 0x09,
+	0xb5, 0xd4, 0x4b, 0xf7, 0x54, 0xcf, 0xfc, 0xab, 0xfa, 0xfa, 0xc3, 0x85, 0x34, 0xa1, 0x67, 0x53,
+	0x7b, 0xa6, 0xa1, 0x97, 0xa0, 0x67, 0xde, 0xb6, 0xbd, 0xd7, 0x37, 0x7a, 0xf3, 0x71, 0x06, 0x3d,
+	0xfb, 0x18, 0xfe, 0x4c, 0x43, 0xcf, 0xa1, 0x76, 0x32, 0x19, 0x8c, 0x3c, 0xfa, 0xc6, 0xa0, 0xcc,
+	0xbd, 0xb3, 0xa1, 0xcf, 0xa1, 0x96, 0x7a, 0x42, 0x9c, 0x9e, 0x67, 0xfe, 0x5d, 0x71, 0x81, 0x70,
+	0x0f, 0x1a, 0x99, 0xd7, 0x3d, 0xf4, 0x48, 0xb1, 0x2c, 0x7a, 0x70, 0x5c, 0x7f, 0x7c, 0x03, 0x55,
+	0xba, 0xb6, 0x0d, 0xcd, 0xec, 0x23, 0x1e, 0x4a, 0x04, 0x16, 0x3e, 0xee, 0xcd, 0x1b, 0x34, 0x28,
+	0xf1, 0x76, 0xfd, 0xc9, 0xbf, 0x06, 0x00, 0xb2, 0x67, 0x52, 0xca, 0x08, 0x1f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -198,6 +2740,29 @@ const _ = grpc.SupportPackageIsVersion4
 type ParserServiceClient interface {
 	Parse(ctx context.Context, in *ParserRequest, opts ...grpc.CallOption) (*ParserResponse, error)
 	ParseTest(ctx context.Context, in *ParserTestRequest, opts ...grpc.CallOption) (*ParserResponse, error)
+	// Fetches an RSS 2.0/1.0, Atom or JSON Feed document and returns its metadata and entries.
+	ParseFeed(ctx context.Context, in *FeedRequest, opts ...grpc.CallOption) (*FeedResponse, error)
+	// Parses many URLs with shared options. Every URL gets its own result and status, a bad URL
+	// does not fail the batch.
+	BatchParse(ctx context.Context, in *BatchParserRequest, opts ...grpc.CallOption) (*BatchParserResponse, error)
+	// Parses the URLs streamed by the client and streams back their results as they complete,
+	// out of order. The server reads the next request only when fewer than the batch
+	// concurrency of the server are in progress, so slow consumers slow the stream down.
+	ParseStream(ctx context.Context, opts ...grpc.CallOption) (ParserService_ParseStreamClient, error)
+	// Parses a url like Parse, and streams the partial response at every stage of the
+	// extraction so link previews can be rendered before the content is extracted. The last
+	// update has the stage PARSE_STAGE_CONTENT and the complete response.
+	ParseProgressive(ctx context.Context, in *ParserRequest, opts ...grpc.CallOption) (ParserService_ParseProgressiveClient, error)
+	// Queues a url to parse in the background and returns the job right away, for pages slower
+	// than the client deadlines. The job is then polled with GetParseJob.
+	SubmitParse(ctx context.Context, in *ParserRequest, opts ...grpc.CallOption) (*ParseJob, error)
+	// Returns a job with its response once it succeeded.
+	GetParseJob(ctx context.Context, in *GetParseJobRequest, opts ...grpc.CallOption) (*ParseJob, error)
+	// Lists the jobs, oldest first, without their responses.
+	ListParseJobs(ctx context.Context, in *ListParseJobsRequest, opts ...grpc.CallOption) (*ListParseJobsResponse, error)
+	// Cancels a queued or running job. Cancelling a cancelled job does nothing, cancelling a
+	// finished job fails with FAILED_PRECONDITION.
+	CancelParseJob(ctx context.Context, in *CancelParseJobRequest, opts ...grpc.CallOption) (*ParseJob, error)
 }
 
 type parserServiceClient struct {
@@ -226,10 +2791,150 @@ func (c *parserServiceClient) ParseTest(ctx context.Context, in *ParserTestReque
 	return out, nil
 }
 
+func (c *parserServiceClient) ParseFeed(ctx context.Context, in *FeedRequest, opts ...grpc.CallOption) (*FeedResponse, error) {
+	out := new(FeedResponse)
+	err := c.cc.Invoke(ctx, "/parser.ParserService/ParseFeed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parserServiceClient) BatchParse(ctx context.Context, in *BatchParserRequest, opts ...grpc.CallOption) (*BatchParserResponse, error) {
+	out := new(BatchParserResponse)
+	err := c.cc.Invoke(ctx, "/parser.ParserService/BatchParse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parserServiceClient) ParseStream(ctx context.Context, opts ...grpc.CallOption) (ParserService_ParseStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ParserService_serviceDesc.Streams[0], "/parser.ParserService/ParseStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &parserServiceParseStreamClient{stream}
+	return x, nil
+}
+
+type ParserService_ParseStreamClient interface {
+	Send(*ParseStreamRequest) error
+	Recv() (*ParseStreamResponse, error)
+	grpc.ClientStream
+}
+
+type parserServiceParseStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *parserServiceParseStreamClient) Send(m *ParseStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *parserServiceParseStreamClient) Recv() (*ParseStreamResponse, error) {
+	m := new(ParseStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *parserServiceClient) ParseProgressive(ctx context.Context, in *ParserRequest, opts ...grpc.CallOption) (ParserService_ParseProgressiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ParserService_serviceDesc.Streams[1], "/parser.ParserService/ParseProgressive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &parserServiceParseProgressiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ParserService_ParseProgressiveClient interface {
+	Recv() (*ParseProgressResponse, error)
+	grpc.ClientStream
+}
+
+type parserServiceParseProgressiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *parserServiceParseProgressiveClient) Recv() (*ParseProgressResponse, error) {
+	m := new(ParseProgressResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *parserServiceClient) SubmitParse(ctx context.Context, in *ParserRequest, opts ...grpc.CallOption) (*ParseJob, error) {
+	out := new(ParseJob)
+	err := c.cc.Invoke(ctx, "/parser.ParserService/SubmitParse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parserServiceClient) GetParseJob(ctx context.Context, in *GetParseJobRequest, opts ...grpc.CallOption) (*ParseJob, error) {
+	out := new(ParseJob)
+	err := c.cc.Invoke(ctx, "/parser.ParserService/GetParseJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parserServiceClient) ListParseJobs(ctx context.Context, in *ListParseJobsRequest, opts ...grpc.CallOption) (*ListParseJobsResponse, error) {
+	out := new(ListParseJobsResponse)
+	err := c.cc.Invoke(ctx, "/parser.ParserService/ListParseJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parserServiceClient) CancelParseJob(ctx context.Context, in *CancelParseJobRequest, opts ...grpc.CallOption) (*ParseJob, error) {
+	out := new(ParseJob)
+	err := c.cc.Invoke(ctx, "/parser.ParserService/CancelParseJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ParserServiceServer is the server API for ParserService service.
 type ParserServiceServer interface {
 	Parse(context.Context, *ParserRequest) (*ParserResponse, error)
 	ParseTest(context.Context, *ParserTestRequest) (*ParserResponse, error)
+	// Fetches an RSS 2.0/1.0, Atom or JSON Feed document and returns its metadata and entries.
+	ParseFeed(context.Context, *FeedRequest) (*FeedResponse, error)
+	// Parses many URLs with shared options. Every URL gets its own result and status, a bad URL
+	// does not fail the batch.
+	BatchParse(context.Context, *BatchParserRequest) (*BatchParserResponse, error)
+	// Parses the URLs streamed by the client and streams back their results as they complete,
+	// out of order. The server reads the next request only when fewer than the batch
+	// concurrency of the server are in progress, so slow consumers slow the stream down.
+	ParseStream(ParserService_ParseStreamServer) error
+	// Parses a url like Parse, and streams the partial response at every stage of the
+	// extraction so link previews can be rendered before the content is extracted. The last
+	// update has the stage PARSE_STAGE_CONTENT and the complete response.
+	ParseProgressive(*ParserRequest, ParserService_ParseProgressiveServer) error
+	// Queues a url to parse in the background and returns the job right away, for pages slower
+	// than the client deadlines. The job is then polled with GetParseJob.
+	SubmitParse(context.Context, *ParserRequest) (*ParseJob, error)
+	// Returns a job with its response once it succeeded.
+	GetParseJob(context.Context, *GetParseJobRequest) (*ParseJob, error)
+	// Lists the jobs, oldest first, without their responses.
+	ListParseJobs(context.Context, *ListParseJobsRequest) (*ListParseJobsResponse, error)
+	// Cancels a queued or running job. Cancelling a cancelled job does nothing, cancelling a
+	// finished job fails with FAILED_PRECONDITION.
+	CancelParseJob(context.Context, *CancelParseJobRequest) (*ParseJob, error)
 }
 
 func RegisterParserServiceServer(s *grpc.Server, srv ParserServiceServer) {
@@ -272,6 +2977,161 @@ func _ParserService_ParseTest_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ParserService_ParseFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParserServiceServer).ParseFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parser.ParserService/ParseFeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParserServiceServer).ParseFeed(ctx, req.(*FeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParserService_BatchParse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchParserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParserServiceServer).BatchParse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parser.ParserService/BatchParse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParserServiceServer).BatchParse(ctx, req.(*BatchParserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParserService_ParseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ParserServiceServer).ParseStream(&parserServiceParseStreamServer{stream})
+}
+
+type ParserService_ParseStreamServer interface {
+	Send(*ParseStreamResponse) error
+	Recv() (*ParseStreamRequest, error)
+	grpc.ServerStream
+}
+
+type parserServiceParseStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *parserServiceParseStreamServer) Send(m *ParseStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *parserServiceParseStreamServer) Recv() (*ParseStreamRequest, error) {
+	m := new(ParseStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ParserService_ParseProgressive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ParserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ParserServiceServer).ParseProgressive(m, &parserServiceParseProgressiveServer{stream})
+}
+
+type ParserService_ParseProgressiveServer interface {
+	Send(*ParseProgressResponse) error
+	grpc.ServerStream
+}
+
+type parserServiceParseProgressiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *parserServiceParseProgressiveServer) Send(m *ParseProgressResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ParserService_SubmitParse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParserServiceServer).SubmitParse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parser.ParserService/SubmitParse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParserServiceServer).SubmitParse(ctx, req.(*ParserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParserService_GetParseJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetParseJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParserServiceServer).GetParseJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parser.ParserService/GetParseJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParserServiceServer).GetParseJob(ctx, req.(*GetParseJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParserService_ListParseJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListParseJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParserServiceServer).ListParseJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parser.ParserService/ListParseJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParserServiceServer).ListParseJobs(ctx, req.(*ListParseJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParserService_CancelParseJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelParseJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParserServiceServer).CancelParseJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parser.ParserService/CancelParseJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParserServiceServer).CancelParseJob(ctx, req.(*CancelParseJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ParserService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "parser.ParserService",
 	HandlerType: (*ParserServiceServer)(nil),
@@ -284,7 +3144,43 @@ var _ParserService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ParseTest",
 			Handler:    _ParserService_ParseTest_Handler,
 		},
+		{
+			MethodName: "ParseFeed",
+			Handler:    _ParserService_ParseFeed_Handler,
+		},
+		{
+			MethodName: "BatchParse",
+			Handler:    _ParserService_BatchParse_Handler,
+		},
+		{
+			MethodName: "SubmitParse",
+			Handler:    _ParserService_SubmitParse_Handler,
+		},
+		{
+			MethodName: "GetParseJob",
+			Handler:    _ParserService_GetParseJob_Handler,
+		},
+		{
+			MethodName: "ListParseJobs",
+			Handler:    _ParserService_ListParseJobs_Handler,
+		},
+		{
+			MethodName: "CancelParseJob",
+			Handler:    _ParserService_CancelParseJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ParseStream",
+			Handler:       _ParserService_ParseStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ParseProgressive",
+			Handler:       _ParserService_ParseProgressive_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "parser.proto",
 }
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"parser/parser/extractor"
+	pb "parser/parser/parserproto"
+)
+
+// BatchParse parses the URLs of the batch concurrently, at most the batch concurrency of the
+// server at a time across all the batches. Each URL gets its own status.
+func (ps *ParserServer) BatchParse(ctx context.Context, input *pb.BatchParserRequest) (*pb.BatchParserResponse, error) {
+	if len(input.Urls) == 0 {
+		err := &extractor.Error{Kind: extractor.KindInvalidInput, Err: fmt.Errorf("no urls")}
+		return nil, toStatusError(err, "urls", "")
+	}
+	if ps.maxBatchSize > 0 && len(input.Urls) > ps.maxBatchSize {
+		err := &extractor.Error{Kind: extractor.KindInvalidInput, Err: fmt.Errorf("%d urls exceed the limit of %d", len(input.Urls), ps.maxBatchSize)}
+		return nil, toStatusError(err, "urls", "")
+	}
+	options, err := withFetchOptions(extractOptions(input), input.FetchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*pb.BatchParserResult, len(input.Urls))
+	var wg sync.WaitGroup
+	for i, inputUrl := range input.Urls {
+		wg.Add(1)
+		go func(i int, inputUrl string) {
+			defer wg.Done()
+			results[i] = ps.parseResult(ctx, inputUrl, options)
+		}(i, inputUrl)
+	}
+	wg.Wait()
+	return &pb.BatchParserResponse{Results: results}, nil
+}
+
+// parseResult parses one URL of BatchParse or ParseStream once a batch slot is free.
+func (ps *ParserServer) parseResult(ctx context.Context, inputUrl string, options []extractor.ExtractOption) *pb.BatchParserResult {
+	result := &pb.BatchParserResult{Url: inputUrl}
+	select {
+	case ps.batchSlots <- struct{}{}:
+		defer func() { <-ps.batchSlots }()
+	case <-ctx.Done():
+		st := status.FromContextError(ctx.Err())
+		result.Code, result.Error = int32(st.Code()), st.Message()
+		return result
+	}
+
+	parsed, err := ps.extractor.ExtractFromURL(ctx, inputUrl, options...)
+	if err != nil {
+		st := status.Convert(toStatusError(err, "url", inputUrl))
+		result.Code, result.Error = int32(st.Code()), st.Message()
+		return result
+	}
+	result.Code = int32(codes.OK)
+	result.Response = toResponse(parsed)
+	return result
+}
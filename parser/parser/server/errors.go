@@ -0,0 +1,55 @@
+package server
+
+import (
+	"log"
+	"os"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"parser/parser/extractor"
+)
+
+// Maps the extractor error kinds to gRPC status codes.
+var errorCodes = map[extractor.ErrorKind]codes.Code{
+	extractor.KindInvalidInput:     codes.InvalidArgument,
+	extractor.KindNotFound:         codes.NotFound,
+	extractor.KindUnavailable:      codes.Unavailable,
+	extractor.KindTimeout:          codes.DeadlineExceeded,
+	extractor.KindUnparsable:       codes.Internal,
+	extractor.KindTooLarge:         codes.ResourceExhausted,
+	extractor.KindCanceled:         codes.Canceled,
+	extractor.KindPermissionDenied: codes.PermissionDenied,
+	extractor.KindBadStatus:        codes.FailedPrecondition,
+	extractor.KindUnsupportedType:  codes.InvalidArgument,
+}
+
+// toStatusError converts an extractor error to a gRPC status error with error details.
+// field is the request field the error relates to ("url", "file_path", ...).
+func toStatusError(err error, field, value string) error {
+	code, ok := errorCodes[extractor.KindOf(err)]
+	if !ok {
+		code = codes.Unknown
+	}
+	if os.IsNotExist(err) {
+		code = codes.NotFound
+	}
+
+	st := status.New(code, err.Error())
+	var detailed *status.Status
+	switch code {
+	case codes.InvalidArgument:
+		detailed, err = st.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: err.Error()}},
+		})
+	case codes.NotFound:
+		detailed, err = st.WithDetails(&errdetails.ResourceInfo{ResourceType: field, ResourceName: value, Description: err.Error()})
+	default:
+		detailed, err = st.WithDetails(&errdetails.DebugInfo{Detail: err.Error()})
+	}
+	if err != nil {
+		log.Println("Error adding status details", err)
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,354 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"parser/parser/extractor"
+	pb "parser/parser/parserproto"
+)
+
+// The defaults of WithJobLimits.
+const (
+	DefaultJobWorkers    = 4
+	DefaultMaxQueuedJobs = 1000
+	DefaultJobRetention  = time.Hour
+)
+
+// The page size of ListParseJobs when the request has none, and the largest one.
+const (
+	defaultJobPageSize = 100
+	maxJobPageSize     = 1000
+)
+
+// WithJobLimits sets the number of workers parsing the SubmitParse jobs, the number of jobs
+// waiting for a worker beyond which SubmitParse fails with ResourceExhausted, and how long
+// finished jobs are kept.
+func WithJobLimits(workers, maxQueued int, retention time.Duration) Option {
+	return func(ps *ParserServer) {
+		if workers > 0 {
+			ps.jobs.workers = workers
+		}
+		if maxQueued > 0 {
+			ps.jobs.maxQueued = maxQueued
+		}
+		if retention > 0 {
+			ps.jobs.retention = retention
+		}
+	}
+}
+
+// job is a url parsed in the background. Its fields are guarded by the mutex of its jobQueue.
+type job struct {
+	id      string
+	seq     uint64
+	url     string
+	options []extractor.ExtractOption
+
+	state    pb.JobState
+	created  time.Time
+	started  time.Time
+	finished time.Time
+	status   *status.Status
+	result   *extractor.Result
+	// cancel stops the extraction of a running job, it is only set while the job runs.
+	cancel context.CancelFunc
+	// webhook is nil when the job has no callback URL.
+	webhook *webhookDelivery
+}
+
+// jobQueue holds the jobs of a server and runs them with a fixed number of workers.
+type jobQueue struct {
+	workers   int
+	maxQueued int
+	retention time.Duration
+	webhooks  webhookConfig
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	lastSeq uint64
+	// queue holds the jobs waiting for a worker, oldest first. Cancelled jobs are removed, so
+	// they do not count against maxQueued.
+	queue []*job
+	// ready is signaled when a job is queued, and broadcast when the queue is stopped.
+	ready       *sync.Cond
+	stopped     bool
+	workersDone sync.WaitGroup
+	// done is closed when the queue is stopped, deliveries tracks the callbacks being delivered.
+	done       chan struct{}
+	deliveries sync.WaitGroup
+}
+
+func newJobQueue() *jobQueue {
+	q := &jobQueue{
+		workers:   DefaultJobWorkers,
+		maxQueued: DefaultMaxQueuedJobs,
+		retention: DefaultJobRetention,
+		webhooks:  newWebhookConfig(),
+		jobs:      make(map[string]*job),
+		done:      make(chan struct{}),
+	}
+	q.ready = sync.NewCond(&q.mu)
+	return q
+}
+
+// start starts the workers, once the limits are set.
+func (q *jobQueue) start(e *extractor.Extractor) {
+	q.workersDone.Add(q.workers)
+	for i := 0; i < q.workers; i++ {
+		go func() {
+			defer q.workersDone.Done()
+			for {
+				j, ctx := q.next()
+				if j == nil {
+					return
+				}
+				q.run(ctx, e, j)
+			}
+		}()
+	}
+}
+
+// next waits for a queued job and marks it running, with the context of its extraction. It
+// returns nil once the queue is stopped.
+func (q *jobQueue) next() (*job, context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.queue) == 0 && !q.stopped {
+		q.ready.Wait()
+	}
+	if q.stopped {
+		return nil, nil
+	}
+	j := q.queue[0]
+	q.queue[0] = nil
+	q.queue = q.queue[1:]
+	ctx, cancel := context.WithCancel(context.Background())
+	j.state, j.started, j.cancel = pb.JobState_JOB_STATE_RUNNING, time.Now(), cancel
+	return j, ctx
+}
+
+// stop cancels the queued and running jobs, and waits for the workers to return and for the
+// callbacks being delivered. The callbacks are not retried once the queue is stopped, so the
+// wait is bounded by the timeout of one attempt.
+func (q *jobQueue) stop() {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+	q.stopped = true
+	close(q.done)
+	for _, j := range q.jobs {
+		q.cancelJob(j, "server stopped")
+	}
+	q.ready.Broadcast()
+	q.mu.Unlock()
+	q.workersDone.Wait()
+	q.deliveries.Wait()
+}
+
+// cancelJob cancels a queued or running job: a queued job leaves the queue, the extraction of a
+// running one is stopped. q.mu must be held.
+func (q *jobQueue) cancelJob(j *job, message string) {
+	switch j.state {
+	case pb.JobState_JOB_STATE_QUEUED:
+		for i, queued := range q.queue {
+			if queued == j {
+				q.queue = append(q.queue[:i], q.queue[i+1:]...)
+				break
+			}
+		}
+	case pb.JobState_JOB_STATE_RUNNING:
+		j.cancel()
+	default:
+		return
+	}
+	j.state, j.finished, j.status = pb.JobState_JOB_STATE_CANCELLED, time.Now(), status.New(codes.Canceled, message)
+	q.notify(j)
+}
+
+// run parses the url of a running job.
+func (q *jobQueue) run(ctx context.Context, e *extractor.Extractor, j *job) {
+	result, err := e.ExtractFromURL(ctx, j.url, j.options...)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j.cancel()
+	j.cancel = nil
+	if j.state == pb.JobState_JOB_STATE_CANCELLED {
+		return
+	}
+	j.finished = time.Now()
+	if err != nil {
+		j.state, j.status = pb.JobState_JOB_STATE_FAILED, status.Convert(toStatusError(err, "url", j.url))
+	} else {
+		j.state, j.status, j.result = pb.JobState_JOB_STATE_SUCCEEDED, status.New(codes.OK, ""), result
+	}
+	q.notify(j)
+}
+
+// prune removes the jobs finished for longer than the retention period. q.mu must be held.
+func (q *jobQueue) prune(now time.Time) {
+	for id, j := range q.jobs {
+		if !j.finished.IsZero() && now.Sub(j.finished) > q.retention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// get returns the job with the given id, or a NotFound status error.
+func (q *jobQueue) get(id string) (*job, error) {
+	q.prune(time.Now())
+	j, ok := q.jobs[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no job %q", id)
+	}
+	return j, nil
+}
+
+// newJobID returns a random job id, so that the ids of the jobs of other clients can not be
+// guessed.
+func newJobID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(fmt.Sprintf("server: could not generate a job id: %v", err))
+	}
+	return hex.EncodeToString(id)
+}
+
+// toParseJob converts a job, with its response when withResponse is set. q.mu must be held.
+func toParseJob(j *job, withResponse bool) *pb.ParseJob {
+	parseJob := &pb.ParseJob{Id: j.id, Url: j.url, State: j.state, CreatedTime: toTimestamp(j.created)}
+	if !j.started.IsZero() {
+		parseJob.StartedTime = toTimestamp(j.started)
+	}
+	if !j.finished.IsZero() {
+		parseJob.FinishedTime = toTimestamp(j.finished)
+	}
+	if j.status != nil {
+		parseJob.Code, parseJob.Error = int32(j.status.Code()), j.status.Message()
+	}
+	if withResponse && j.result != nil {
+		parseJob.Response = toResponse(j.result)
+	}
+	parseJob.Webhook = toWebhookDelivery(j.webhook)
+	return parseJob
+}
+
+// SubmitParse queues a url to parse in the background and returns its job.
+func (ps *ParserServer) SubmitParse(ctx context.Context, input *pb.ParserRequest) (*pb.ParseJob, error) {
+	options, err := withFetchOptions(extractOptions(input), input.FetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	q := ps.jobs
+	var webhook *webhookDelivery
+	if input.CallbackUrl != "" {
+		if q.webhooks.secret == nil {
+			return nil, status.Error(codes.FailedPrecondition, "webhooks are not configured on this server")
+		}
+		if err := checkCallbackURL(input.CallbackUrl); err != nil {
+			return nil, err
+		}
+		webhook = &webhookDelivery{url: input.CallbackUrl, state: pb.WebhookState_WEBHOOK_STATE_PENDING}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return nil, status.Error(codes.Unavailable, "server stopped")
+	}
+	if len(q.queue) >= q.maxQueued {
+		return nil, status.Errorf(codes.ResourceExhausted, "%d jobs are already queued", q.maxQueued)
+	}
+	q.prune(time.Now())
+	q.lastSeq++
+	j := &job{id: newJobID(), seq: q.lastSeq, url: input.Url, options: options,
+		state: pb.JobState_JOB_STATE_QUEUED, created: time.Now(), webhook: webhook}
+	q.queue = append(q.queue, j)
+	q.jobs[j.id] = j
+	q.ready.Signal()
+	return toParseJob(j, false), nil
+}
+
+// GetParseJob returns a job, with its response once it succeeded.
+func (ps *ParserServer) GetParseJob(ctx context.Context, input *pb.GetParseJobRequest) (*pb.ParseJob, error) {
+	q := ps.jobs
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j, err := q.get(input.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toParseJob(j, true), nil
+}
+
+// ListParseJobs lists the jobs in submission order, without their responses. The page token is
+// the sequence number of the last job of the previous page.
+func (ps *ParserServer) ListParseJobs(ctx context.Context, input *pb.ListParseJobsRequest) (*pb.ListParseJobsResponse, error) {
+	pageSize := int(input.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultJobPageSize
+	}
+	if pageSize > maxJobPageSize {
+		pageSize = maxJobPageSize
+	}
+	var after uint64
+	if input.PageToken != "" {
+		var err error
+		if after, err = strconv.ParseUint(input.PageToken, 10, 64); err != nil {
+			err := &extractor.Error{Kind: extractor.KindInvalidInput, Err: fmt.Errorf("invalid page token")}
+			return nil, toStatusError(err, "page_token", input.PageToken)
+		}
+	}
+
+	q := ps.jobs
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune(time.Now())
+	var jobs []*job
+	for _, j := range q.jobs {
+		if j.seq > after && (input.State == pb.JobState_JOB_STATE_UNSPECIFIED || j.state == input.State) {
+			jobs = append(jobs, j)
+		}
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].seq < jobs[k].seq })
+
+	response := &pb.ListParseJobsResponse{}
+	if len(jobs) > pageSize {
+		jobs = jobs[:pageSize]
+		response.NextPageToken = strconv.FormatUint(jobs[pageSize-1].seq, 10)
+	}
+	for _, j := range jobs {
+		response.Jobs = append(response.Jobs, toParseJob(j, false))
+	}
+	return response, nil
+}
+
+// CancelParseJob cancels a queued or running job. A running extraction is stopped, and the job
+// is cancelled right away.
+func (ps *ParserServer) CancelParseJob(ctx context.Context, input *pb.CancelParseJobRequest) (*pb.ParseJob, error) {
+	q := ps.jobs
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j, err := q.get(input.Id)
+	if err != nil {
+		return nil, err
+	}
+	switch j.state {
+	case pb.JobState_JOB_STATE_QUEUED, pb.JobState_JOB_STATE_RUNNING:
+		q.cancelJob(j, "job cancelled")
+	case pb.JobState_JOB_STATE_CANCELLED:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "job %q is already %s", j.id, j.state)
+	}
+	return toParseJob(j, false), nil
+}
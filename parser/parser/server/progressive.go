@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+
+	"parser/parser/extractor"
+	pb "parser/parser/parserproto"
+)
+
+// The ParseProgressive stages of the extractor progress stages.
+var progressStages = map[extractor.ProgressStage]pb.ParseStage{
+	extractor.ProgressFetched:   pb.ParseStage_PARSE_STAGE_FETCHED,
+	extractor.ProgressMetadata:  pb.ParseStage_PARSE_STAGE_METADATA,
+	extractor.ProgressThumbnail: pb.ParseStage_PARSE_STAGE_THUMBNAIL,
+}
+
+// ParseProgressive parses a url like Parse, and sends the partial response at every stage of
+// the extraction before the complete one. The extraction stops when the client is gone.
+func (ps *ParserServer) ParseProgressive(input *pb.ParserRequest, stream pb.ParserService_ParseProgressiveServer) error {
+	options, err := withFetchOptions(extractOptions(input), input.FetchOptions)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// The progress function is called from this goroutine, the partial result is converted
+	// before the extraction goes on.
+	var sendErr error
+	options = append(options, extractor.WithProgress(func(stage extractor.ProgressStage, partial *extractor.Result) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&pb.ParseProgressResponse{Stage: progressStages[stage], Response: toResponse(partial)})
+		if sendErr != nil {
+			cancel()
+		}
+	}))
+	result, err := ps.extractor.ExtractFromURL(ctx, input.Url, options...)
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return toStatusError(err, "url", input.Url)
+	}
+	return stream.Send(&pb.ParseProgressResponse{Stage: pb.ParseStage_PARSE_STAGE_CONTENT, Response: toResponse(result)})
+}
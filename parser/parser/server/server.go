@@ -0,0 +1,386 @@
+// Package server implements the ParserService gRPC service on top of the extractor package.
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+	"parser/parser/extractor"
+	pb "parser/parser/parserproto"
+)
+
+// The defaults of WithBatchLimits.
+const (
+	DefaultMaxBatchSize     = 100
+	DefaultBatchConcurrency = 8
+)
+
+// ParserServer is a thin gRPC adapter over an extractor.Extractor.
+type ParserServer struct {
+	extractor    *extractor.Extractor
+	maxBatchSize int
+	// batchSlots holds a value per URL being parsed by BatchParse and ParseStream, across all
+	// the calls.
+	batchSlots chan struct{}
+	jobs       *jobQueue
+}
+
+// Option configures a ParserServer.
+type Option func(*ParserServer)
+
+// WithBatchLimits sets the largest number of URLs of a BatchParse request (no limit if zero) and
+// the number of URLs parsed at the same time by all the BatchParse and ParseStream calls.
+func WithBatchLimits(maxBatchSize, concurrency int) Option {
+	return func(ps *ParserServer) {
+		ps.maxBatchSize = maxBatchSize
+		if concurrency > 0 {
+			ps.batchSlots = make(chan struct{}, concurrency)
+		}
+	}
+}
+
+// NewParserServer returns a ParserServer serving requests with the given extractor. It starts
+// the workers of the SubmitParse jobs, which run until Stop is called.
+func NewParserServer(e *extractor.Extractor, options ...Option) *ParserServer {
+	ps := &ParserServer{
+		extractor:    e,
+		maxBatchSize: DefaultMaxBatchSize,
+		batchSlots:   make(chan struct{}, DefaultBatchConcurrency),
+		jobs:         newJobQueue(),
+	}
+	for _, option := range options {
+		option(ps)
+	}
+	ps.jobs.start(e)
+	return ps
+}
+
+// Stop cancels the queued and running SubmitParse jobs, and waits for their workers to return.
+// SubmitParse then fails with Unavailable. Stop also waits for the callbacks being delivered,
+// those of the cancelled jobs included, but the failed attempts are not retried anymore.
+func (ps *ParserServer) Stop() {
+	ps.jobs.stop()
+}
+
+func (ps *ParserServer) Parse(ctx context.Context, input *pb.ParserRequest) (*pb.ParserResponse, error) {
+	options, err := withFetchOptions(extractOptions(input), input.FetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ps.extractor.ExtractFromURL(ctx, input.Url, options...)
+	if err != nil {
+		log.Println(err)
+		return nil, toStatusError(err, "url", input.Url)
+	}
+	return toResponse(result), nil
+}
+
+// ParseFeed parses an RSS, Atom or JSON feed, and the links of its entries if requested.
+func (ps *ParserServer) ParseFeed(ctx context.Context, input *pb.FeedRequest) (*pb.FeedResponse, error) {
+	options, err := withFetchOptions(extractOptions(input), input.FetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	feedOptions := extractor.FeedOptions{MaxEntries: int(input.MaxEntries), ParseEntries: input.ParseEntries}
+	feed, err := ps.extractor.ExtractFeed(ctx, input.Url, feedOptions, options...)
+	if err != nil {
+		log.Println(err)
+		return nil, toStatusError(err, "url", input.Url)
+	}
+	return toFeedResponse(feed), nil
+}
+
+// This method is for testing purposes. It is the equivalent of the Parse method!
+// Instead of a URL, it takes a file path which contains html page files.
+func (ps *ParserServer) ParseTest(ctx context.Context, input *pb.ParserTestRequest) (*pb.ParserResponse, error) {
+	f, err := os.Open(input.FilePath)
+	if err != nil {
+		log.Println(err)
+		return nil, toStatusError(err, "file_path", input.FilePath)
+	}
+	defer f.Close()
+
+	result, err := ps.extractor.ExtractFromReader(ctx, f, "", extractOptions(input)...)
+	if err != nil {
+		return nil, toStatusError(err, "file_path", input.FilePath)
+	}
+	return toResponse(result), nil
+}
+
+// extractRequest holds the extraction options common to ParserRequest, ParserTestRequest,
+// FeedRequest and BatchParserRequest.
+type extractRequest interface {
+	GetContentMode() pb.ContentMode
+	GetContentFormats() []pb.ContentFormat
+	GetMaxImages() int32
+	GetProbeImages() bool
+}
+
+// extractOptions converts the request options to extractor options.
+func extractOptions(request extractRequest) []extractor.ExtractOption {
+	var options []extractor.ExtractOption
+	if request.GetContentMode() == pb.ContentMode_CONTENT_MODE_READABILITY {
+		options = append(options, extractor.WithContentMode(extractor.ContentModeReadability))
+	}
+	for _, format := range request.GetContentFormats() {
+		switch format {
+		case pb.ContentFormat_CONTENT_FORMAT_MARKDOWN:
+			options = append(options, extractor.WithMarkdown())
+		case pb.ContentFormat_CONTENT_FORMAT_HTML:
+			options = append(options, extractor.WithSanitizedHTML())
+		}
+	}
+	if request.GetMaxImages() > 0 {
+		options = append(options, extractor.WithMaxImages(int(request.GetMaxImages())))
+	}
+	if request.GetProbeImages() {
+		options = append(options, extractor.WithImageProbing())
+	}
+	return options
+}
+
+// withFetchOptions appends the fetch options of the request, if any, to options.
+func withFetchOptions(options []extractor.ExtractOption, fetchOptions *pb.FetchOptions) ([]extractor.ExtractOption, error) {
+	if fetchOptions == nil {
+		return options, nil
+	}
+	fetch, field, err := toFetchOptions(fetchOptions)
+	if err != nil {
+		return nil, toStatusError(&extractor.Error{Kind: extractor.KindInvalidInput, Err: err}, "fetch_options."+field, "")
+	}
+	return append(options, extractor.WithFetchOptions(fetch)), nil
+}
+
+// toFetchOptions converts the fetch options of a request. It also returns the name of the
+// invalid field, if any.
+func toFetchOptions(options *pb.FetchOptions) (extractor.FetchOptions, string, error) {
+	fetch := extractor.FetchOptions{
+		MaxBodyBytes:   options.MaxBodyBytes,
+		UserAgent:      options.UserAgent,
+		AcceptLanguage: options.AcceptLanguage,
+		Headers:        options.Headers,
+	}
+	timeouts := []struct {
+		field string
+		value *duration.Duration
+		fetch *time.Duration
+	}{
+		{field: "timeout", value: options.Timeout, fetch: &fetch.Timeout},
+		{field: "connect_timeout", value: options.ConnectTimeout, fetch: &fetch.ConnectTimeout},
+		{field: "read_timeout", value: options.ReadTimeout, fetch: &fetch.ReadTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value == nil {
+			continue
+		}
+		value, err := ptypes.Duration(timeout.value)
+		if err != nil {
+			return fetch, timeout.field, err
+		}
+		*timeout.fetch = value
+	}
+	return fetch, "", nil
+}
+
+func toResponse(result *extractor.Result) *pb.ParserResponse {
+	return &pb.ParserResponse{
+		Title:           result.Title,
+		ThumbnailUrl:    result.ThumbnailURL,
+		Content:         result.Content,
+		HasTitle:        result.Title != "",
+		HasThumbnail:    result.ThumbnailURL != "",
+		HasContent:      result.Content != "",
+		Description:     result.Description,
+		CanonicalUrl:    result.CanonicalURL,
+		SiteName:        result.SiteName,
+		Authors:         result.Authors,
+		PublishedTime:   toTimestamp(result.PublishedTime),
+		ModifiedTime:    toTimestamp(result.ModifiedTime),
+		Language:        result.Language,
+		Keywords:        result.Keywords,
+		FaviconUrl:      result.FaviconURL,
+		WordCount:       int32(result.WordCount),
+		FinalUrl:        result.URL,
+		OpenGraph:       toOpenGraph(result.OpenGraph),
+		TwitterCard:     toTwitterCard(result.TwitterCard),
+		StructuredData:  toStructuredData(result.StructuredData),
+		JsonLd:          result.JSONLD,
+		Extractor:       result.Extractor,
+		Blocks:          toBlocks(result.Blocks),
+		ContentMarkdown: result.ContentMarkdown,
+		ContentHtml:     result.ContentHTML,
+		Images:          toImages(result.Images),
+		ThumbnailProbe:  toImageProbe(result.ThumbnailProbe),
+		Charset:         result.Charset,
+		ContentType:     result.ContentType,
+		PageCount:       int32(result.PageCount),
+		AmpUrl:          result.AMPURL,
+		Feeds:           toAlternateLinks(result.Feeds),
+		OembedEndpoints: toAlternateLinks(result.OEmbedEndpoints),
+	}
+}
+
+func toFeedResponse(feed *extractor.Feed) *pb.FeedResponse {
+	response := &pb.FeedResponse{
+		Url:         feed.URL,
+		Format:      feed.Format,
+		Title:       feed.Title,
+		Description: feed.Description,
+		Link:        feed.Link,
+		Language:    feed.Language,
+		ImageUrl:    feed.ImageURL,
+		Updated:     toTimestamp(feed.Updated),
+	}
+	for _, entry := range feed.Entries {
+		feedEntry := &pb.FeedEntry{
+			Id:        entry.ID,
+			Title:     entry.Title,
+			Link:      entry.Link,
+			Published: toTimestamp(entry.Published),
+			Updated:   toTimestamp(entry.Updated),
+			Summary:   entry.Summary,
+			ImageUrl:  entry.ImageURL,
+			Authors:   entry.Authors,
+		}
+		if entry.Article != nil {
+			feedEntry.Article = toResponse(entry.Article)
+		}
+		if entry.ArticleErr != nil {
+			feedEntry.ArticleError = entry.ArticleErr.Error()
+		}
+		response.Entries = append(response.Entries, feedEntry)
+	}
+	return response
+}
+
+func toAlternateLinks(links []extractor.AlternateLink) []*pb.AlternateLink {
+	var alternateLinks []*pb.AlternateLink
+	for _, link := range links {
+		alternateLinks = append(alternateLinks, &pb.AlternateLink{Url: link.URL, Type: link.Type, Title: link.Title})
+	}
+	return alternateLinks
+}
+
+func toImages(images []extractor.ImageCandidate) []*pb.ImageCandidate {
+	var pbImages []*pb.ImageCandidate
+	for _, image := range images {
+		pbImages = append(pbImages, &pb.ImageCandidate{
+			Url:      image.URL,
+			Score:    image.Score,
+			Reasons:  image.Reasons,
+			Width:    int32(image.Width),
+			Height:   int32(image.Height),
+			Alt:      image.Alt,
+			Probe:    toImageProbe(image.Probe),
+			Rejected: image.Rejected,
+		})
+	}
+	return pbImages
+}
+
+func toImageProbe(probe *extractor.ImageProbe) *pb.ImageProbe {
+	if probe == nil {
+		return nil
+	}
+	return &pb.ImageProbe{
+		ContentType: probe.ContentType,
+		Width:       int32(probe.Width),
+		Height:      int32(probe.Height),
+	}
+}
+
+// Maps the extractor block types to the proto ones.
+var blockTypes = map[extractor.BlockType]pb.ContentBlock_Type{
+	extractor.BlockParagraph: pb.ContentBlock_PARAGRAPH,
+	extractor.BlockHeading:   pb.ContentBlock_HEADING,
+	extractor.BlockList:      pb.ContentBlock_LIST,
+	extractor.BlockQuote:     pb.ContentBlock_BLOCKQUOTE,
+	extractor.BlockCode:      pb.ContentBlock_CODE,
+	extractor.BlockTable:     pb.ContentBlock_TABLE,
+}
+
+func toBlocks(blocks []extractor.Block) []*pb.ContentBlock {
+	var pbBlocks []*pb.ContentBlock
+	for _, block := range blocks {
+		pbBlock := &pb.ContentBlock{
+			Type:    blockTypes[block.Type],
+			Text:    block.Text,
+			Level:   int32(block.Level),
+			Ordered: block.Ordered,
+			Items:   block.Items,
+		}
+		for _, row := range block.Rows {
+			pbBlock.Rows = append(pbBlock.Rows, &pb.TableRow{Cells: row})
+		}
+		pbBlocks = append(pbBlocks, pbBlock)
+	}
+	return pbBlocks
+}
+
+func toOpenGraph(og *extractor.OpenGraph) *pb.OpenGraph {
+	if og == nil {
+		return nil
+	}
+	return &pb.OpenGraph{
+		Title:       og.Title,
+		Type:        og.Type,
+		Url:         og.URL,
+		Description: og.Description,
+		SiteName:    og.SiteName,
+		Locale:      og.Locale,
+		Images:      og.Images,
+		Video:       og.Video,
+	}
+}
+
+func toTwitterCard(card *extractor.TwitterCard) *pb.TwitterCard {
+	if card == nil {
+		return nil
+	}
+	return &pb.TwitterCard{
+		Card:        card.Card,
+		Site:        card.Site,
+		Creator:     card.Creator,
+		Title:       card.Title,
+		Description: card.Description,
+		Image:       card.Image,
+		ImageAlt:    card.ImageAlt,
+	}
+}
+
+func toStructuredData(data *extractor.StructuredData) *pb.StructuredData {
+	if data == nil {
+		return nil
+	}
+	return &pb.StructuredData{
+		Type:          data.Type,
+		Headline:      data.Headline,
+		Description:   data.Description,
+		Authors:       data.Authors,
+		DatePublished: toTimestamp(data.DatePublished),
+		DateModified:  toTimestamp(data.DateModified),
+		Images:        data.Images,
+		ArticleBody:   data.ArticleBody,
+		Publisher:     data.Publisher,
+		Keywords:      data.Keywords,
+		Url:           data.URL,
+	}
+}
+
+// toTimestamp converts t to a protobuf Timestamp. Zero or out of range times are left unset.
+func toTimestamp(t time.Time) *tspb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return ts
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/status"
+	"parser/parser/extractor"
+	pb "parser/parser/parserproto"
+)
+
+// ParseStream parses the URLs streamed by the client and sends their results as they complete.
+// At most the batch concurrency of the server are received and not sent yet: the next request
+// is only read once a result has been handed to the sender, which blocks while the client does
+// not read. The buffering of a stream is thus bounded, and gRPC flow control slows the client.
+func (ps *ParserServer) ParseStream(stream pb.ParserService_ParseStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	results := make(chan *pb.ParseStreamResponse)
+	inFlight := make(chan struct{}, cap(ps.batchSlots))
+	recvErr := make(chan error, 1)
+	var wg sync.WaitGroup
+	go func() {
+		recvErr <- ps.receiveStream(ctx, stream, inFlight, results, &wg)
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+	if err := <-recvErr; err != nil && err != io.EOF {
+		return status.Convert(err).Err()
+	}
+	return nil
+}
+
+// receiveStream reads the requests of the stream while fewer than cap(inFlight) are in progress,
+// and parses each of them in its own goroutine. It returns the error which ended the stream,
+// io.EOF when the client closed it.
+func (ps *ParserServer) receiveStream(ctx context.Context, stream pb.ParserService_ParseStreamServer,
+	inFlight chan struct{}, results chan<- *pb.ParseStreamResponse, wg *sync.WaitGroup) error {
+	for {
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		request, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response := &pb.ParseStreamResponse{Id: request.Id, Result: ps.streamResult(ctx, request.Request)}
+			select {
+			case results <- response:
+			case <-ctx.Done():
+			}
+			<-inFlight
+		}()
+	}
+}
+
+// streamResult parses the URL of a ParseStream request with its options.
+func (ps *ParserServer) streamResult(ctx context.Context, request *pb.ParserRequest) *pb.BatchParserResult {
+	if request == nil {
+		err := &extractor.Error{Kind: extractor.KindInvalidInput, Err: fmt.Errorf("no request")}
+		st := status.Convert(toStatusError(err, "request", ""))
+		return &pb.BatchParserResult{Code: int32(st.Code()), Error: st.Message()}
+	}
+	options, err := withFetchOptions(extractOptions(request), request.FetchOptions)
+	if err != nil {
+		st := status.Convert(err)
+		return &pb.BatchParserResult{Url: request.Url, Code: int32(st.Code()), Error: st.Message()}
+	}
+	return ps.parseResult(ctx, request.Url, options)
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"parser/parser/extractor"
+	pb "parser/parser/parserproto"
+)
+
+// The defaults of WithWebhooks.
+const (
+	DefaultWebhookAttempts = 5
+	DefaultWebhookBackoff  = time.Second
+)
+
+const (
+	// maxWebhookBackoff caps the exponential backoff between two attempts.
+	maxWebhookBackoff = time.Minute
+	// webhookTimeout is the timeout of every attempt.
+	webhookTimeout = 10 * time.Second
+)
+
+// The headers of the webhook requests.
+const (
+	WebhookJobIDHeader     = "X-Parser-Job-Id"
+	WebhookJobStateHeader  = "X-Parser-Job-State"
+	WebhookTimestampHeader = "X-Parser-Timestamp"
+	WebhookSignatureHeader = "X-Parser-Signature"
+)
+
+// WithWebhooks enables the callback_url of SubmitParse: the callbacks are signed with secret,
+// and attempted at most attempts times, backoff after the first attempt then twice longer after
+// every attempt, up to a minute. SubmitParse rejects the callbacks when there is no secret.
+func WithWebhooks(secret []byte, attempts int, backoff time.Duration) Option {
+	return func(ps *ParserServer) {
+		ps.jobs.webhooks.secret = secret
+		if attempts > 0 {
+			ps.jobs.webhooks.attempts = attempts
+		}
+		if backoff > 0 {
+			ps.jobs.webhooks.backoff = backoff
+		}
+	}
+}
+
+// WebhookSignature returns the value of the X-Parser-Signature header of a webhook request:
+// "sha256=" and the hex HMAC-SHA256 with secret of the X-Parser-Timestamp header, a dot and
+// the body. Receivers compare it to the header with hmac.Equal, and should reject old timestamps.
+func WebhookSignature(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	io.WriteString(mac, timestamp+".")
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookConfig is the webhook configuration of a jobQueue.
+type webhookConfig struct {
+	secret   []byte
+	attempts int
+	backoff  time.Duration
+	client   *http.Client
+}
+
+func newWebhookConfig() webhookConfig {
+	return webhookConfig{
+		attempts: DefaultWebhookAttempts,
+		backoff:  DefaultWebhookBackoff,
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// webhookDelivery is the delivery of the callback of a job. Its fields are guarded by the mutex
+// of the jobQueue.
+type webhookDelivery struct {
+	url            string
+	state          pb.WebhookState
+	attempts       int
+	lastAttempt    time.Time
+	lastStatusCode int
+	lastError      string
+}
+
+// checkCallbackURL returns an InvalidArgument status error unless callbackUrl is an absolute
+// http or https URL.
+func checkCallbackURL(callbackUrl string) error {
+	u, err := url.Parse(callbackUrl)
+	if err == nil && (u.Scheme != "http" && u.Scheme != "https" || u.Host == "") {
+		err = fmt.Errorf("expected an http or https URL")
+	}
+	if err != nil {
+		return toStatusError(&extractor.Error{Kind: extractor.KindInvalidInput, Err: err}, "callback_url", callbackUrl)
+	}
+	return nil
+}
+
+// notify starts the delivery of the callback of a finished job, if any. q.mu must be held.
+func (q *jobQueue) notify(j *job) {
+	if j.webhook == nil {
+		return
+	}
+	body, err := webhookBody(j)
+	if err != nil {
+		j.webhook.state, j.webhook.lastError = pb.WebhookState_WEBHOOK_STATE_FAILED, err.Error()
+		return
+	}
+	q.deliveries.Add(1)
+	go func() {
+		defer q.deliveries.Done()
+		q.deliver(j, body)
+	}()
+}
+
+// webhookBody returns the JSON-encoded response of a succeeded job, else its status.
+func webhookBody(j *job) ([]byte, error) {
+	if j.state == pb.JobState_JOB_STATE_SUCCEEDED {
+		var body bytes.Buffer
+		err := (&jsonpb.Marshaler{}).Marshal(&body, toResponse(j.result))
+		return body.Bytes(), err
+	}
+	return json.Marshal(struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+	}{int32(j.status.Code()), j.status.Message()})
+}
+
+// deliver posts the callback of a job until it is delivered, the attempts are exhausted, the
+// callback answers with a status which is not worth retrying or the queue is stopped.
+func (q *jobQueue) deliver(j *job, body []byte) {
+	q.mu.Lock()
+	callbackUrl, state := j.webhook.url, j.state
+	q.mu.Unlock()
+
+	backoff := q.webhooks.backoff
+	for attempt := 1; ; attempt++ {
+		statusCode, retryable, err := q.post(callbackUrl, j.id, state, body)
+
+		q.mu.Lock()
+		w := j.webhook
+		w.attempts, w.lastAttempt, w.lastStatusCode, w.lastError = attempt, time.Now(), statusCode, ""
+		retry := false
+		switch {
+		case err == nil:
+			w.state = pb.WebhookState_WEBHOOK_STATE_DELIVERED
+		case attempt < q.webhooks.attempts && retryable && !q.stopped:
+			w.lastError = err.Error()
+			retry = true
+		default:
+			w.state, w.lastError = pb.WebhookState_WEBHOOK_STATE_FAILED, err.Error()
+		}
+		q.mu.Unlock()
+		if !retry {
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-q.done:
+			timer.Stop()
+			q.mu.Lock()
+			w.state = pb.WebhookState_WEBHOOK_STATE_FAILED
+			q.mu.Unlock()
+			return
+		}
+		if backoff *= 2; backoff > maxWebhookBackoff {
+			backoff = maxWebhookBackoff
+		}
+	}
+}
+
+// post makes one attempt to deliver a callback. It returns the HTTP status of the response, 0
+// if there was none, and an error unless the status is 2xx, with whether another attempt may
+// succeed: a request which can not be built will never be sent.
+func (q *jobQueue) post(callbackUrl, jobID string, state pb.JobState, body []byte) (int, bool, error) {
+	request, err := http.NewRequest(http.MethodPost, callbackUrl, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookJobIDHeader, jobID)
+	request.Header.Set(WebhookJobStateHeader, state.String())
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, WebhookSignature(q.webhooks.secret, timestamp, body))
+
+	// Network errors are retried.
+	response, err := q.webhooks.client.Do(request)
+	if err != nil {
+		return 0, true, err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(response.Body, 64<<10))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, retryableStatus(response.StatusCode), fmt.Errorf("HTTP status %s", response.Status)
+	}
+	return response.StatusCode, false, nil
+}
+
+// retryableStatus tells whether an attempt which got an HTTP response with statusCode is worth
+// retrying.
+func retryableStatus(statusCode int) bool {
+	return statusCode >= 500 || statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+}
+
+func toWebhookDelivery(w *webhookDelivery) *pb.WebhookDelivery {
+	if w == nil {
+		return nil
+	}
+	return &pb.WebhookDelivery{
+		Url:             w.url,
+		State:           w.state,
+		Attempts:        int32(w.attempts),
+		LastAttemptTime: toTimestamp(w.lastAttempt),
+		LastStatusCode:  int32(w.lastStatusCode),
+		LastError:       w.lastError,
+	}
+}